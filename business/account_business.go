@@ -0,0 +1,695 @@
+package business
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/flow/log"
+	"github.com/go-flow/template-api/config"
+	"github.com/go-flow/template-api/db"
+	apperrors "github.com/go-flow/template-api/domain/errors"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/pkg/password"
+	"github.com/go-flow/template-api/pkg/token"
+	"github.com/go-flow/template-api/services"
+	"github.com/jinzhu/gorm"
+)
+
+var (
+	// ErrInvalidCredentials is returned when login email and password do not match
+	ErrInvalidCredentials = apperrors.Unauthorized(apperrors.CodeInvalidCredentials, "invalid email or password")
+
+	// ErrEmailTaken is returned when registering account with already used email
+	ErrEmailTaken = apperrors.Conflict(apperrors.CodeEmailTaken, "email is already registered")
+
+	// ErrInvalidRefreshToken is returned when refresh token is unknown, expired or revoked
+	ErrInvalidRefreshToken = apperrors.Unauthorized(apperrors.CodeInvalidRefreshToken, "invalid refresh token")
+
+	// ErrEmailAlreadyVerified is returned when requesting verification of already verified email
+	ErrEmailAlreadyVerified = apperrors.Conflict(apperrors.CodeEmailAlreadyVerified, "email is already verified")
+
+	// ErrPhoneNumberMissing is returned when requesting phone verification without phone number
+	ErrPhoneNumberMissing = apperrors.Validation(apperrors.CodePhoneNumberMissing, "phone number is not set")
+
+	// ErrPhoneAlreadyVerified is returned when requesting verification of already verified phone number
+	ErrPhoneAlreadyVerified = apperrors.Conflict(apperrors.CodePhoneAlreadyVerified, "phone number is already verified")
+
+	// ErrThrottled is returned when operation is requested again too soon
+	ErrThrottled = apperrors.RateLimited(apperrors.CodeThrottled, "too many requests, please try again later")
+
+	// ErrAccountInactive is returned when authenticating user whose account is not active
+	ErrAccountInactive = apperrors.Forbidden(apperrors.CodeAccountInactive, "account is not active")
+
+	// ErrInvalidTwoFactorLogin is returned when two-factor login challenge or code is not valid
+	ErrInvalidTwoFactorLogin = apperrors.Unauthorized(apperrors.CodeInvalidTwoFactorLogin, "invalid two-factor challenge or code")
+
+	// ErrAccountSuspended is returned when authenticating user whose account is suspended
+	ErrAccountSuspended = apperrors.Forbidden(apperrors.CodeAccountSuspended, "account is suspended")
+)
+
+// passwordResetQueueSize is number of password reset requests waiting to be processed in background
+const passwordResetQueueSize = 100
+
+// AccountBusiness defines set of business rules related to user accounts
+type AccountBusiness interface {
+	// AccountBusiness ensures interface implementation
+	AccountBusiness() string
+
+	// Register creates new user account with given credentials
+	Register(ctx context.Context, firstName string, lastName string, email string, plainPassword string) (*models.User, error)
+
+	// Login checks given credentials and issues access and refresh tokens
+	//
+	// when user has two-factor authentication enabled, challenge for second login step is returned instead of tokens
+	Login(ctx context.Context, email string, plainPassword string) (*models.AuthTokens, *models.TwoFactorChallenge, error)
+
+	// LoginTwoFactor completes login challenge with authentication or recovery code and issues access and refresh tokens
+	LoginTwoFactor(ctx context.Context, challengeToken string, code string) (*models.AuthTokens, error)
+
+	// Refresh rotates given refresh token and issues new access and refresh tokens
+	Refresh(ctx context.Context, refreshToken string) (*models.AuthTokens, error)
+
+	// Logout revokes given refresh token, or all user refresh tokens when all is true
+	Logout(ctx context.Context, userID uint64, refreshToken string, all bool) error
+
+	// Authenticate returns user model for given access token
+	Authenticate(ctx context.Context, accessToken string) (*models.User, error)
+
+	// SendEmailVerification sends email verification link to user with given id
+	SendEmailVerification(ctx context.Context, userID uint64) error
+
+	// VerifyEmail marks email of user who received given verification token as verified
+	VerifyEmail(ctx context.Context, verificationToken string) (*models.User, error)
+
+	// SendPhoneVerification sends one time verification code to phone number of user with given id
+	SendPhoneVerification(ctx context.Context, userID uint64) error
+
+	// VerifyPhone marks phone number of user with given id as verified using one time code
+	VerifyPhone(ctx context.Context, userID uint64, code string) (*models.User, error)
+
+	// ForgotPassword queues sending of password reset link to user with given email
+	//
+	// it does not report whether account with given email exists
+	ForgotPassword(ctx context.Context, email string) error
+
+	// ResetPassword sets new password for user who received given reset token
+	// and revokes all refresh tokens issued to the user
+	ResetPassword(ctx context.Context, resetToken string, plainPassword string) error
+
+	// EnrollTwoFactor starts two-factor enrollment for user with given id
+	EnrollTwoFactor(ctx context.Context, userID uint64) (*models.TwoFactorEnrollment, error)
+
+	// ConfirmTwoFactor enables two-factor authentication and returns recovery codes
+	ConfirmTwoFactor(ctx context.Context, userID uint64, code string) (*models.TwoFactorRecoveryCodes, error)
+
+	// DisableTwoFactor disables two-factor authentication after checking authentication or recovery code
+	DisableTwoFactor(ctx context.Context, userID uint64, code string) error
+
+	// BootstrapAdmin grants admin role to already verified user with configured admin email
+	BootstrapAdmin(ctx context.Context) error
+}
+
+// NewAccountBusiness creates new account business rules implementation instance
+func NewAccountBusiness(app *flow.App) AccountBusiness {
+	cfg := app.AppConfig.(config.AppConfig)
+
+	tokens, err := token.NewManager(cfg.JwtSigningMethod, cfg.JwtSecret, cfg.JwtPrivateKeyPath, cfg.JwtPublicKeyPath, cfg.JwtIssuer)
+	if err != nil {
+		app.Logger.Fatal(err.Error())
+	}
+
+	bl := &accountBusiness{
+		logger:                  app.Logger,
+		tokens:                  tokens,
+		accessTokenTTL:          time.Minute * time.Duration(cfg.JwtAccessTokenTTL),
+		refreshTokenTTL:         time.Hour * time.Duration(cfg.JwtRefreshTokenTTL),
+		verificationTokenTTL:    time.Hour * time.Duration(cfg.EmailVerificationTokenTTL),
+		verificationResendDelay: time.Second * time.Duration(cfg.EmailVerificationResendInterval),
+		otpResendDelay:          time.Second * time.Duration(cfg.OtpResendInterval),
+		passwordResetTokenTTL:   time.Minute * time.Duration(cfg.PasswordResetTokenTTL),
+		twoFactorChallengeTTL:   time.Minute * time.Duration(cfg.TwoFactorChallengeTTL),
+		adminEmail:              cfg.AdminEmail,
+		passwordResets:          make(chan passwordReset, passwordResetQueueSize),
+	}
+
+	go bl.processPasswordResets()
+
+	return bl
+}
+
+// passwordReset is password reset request processed in background
+type passwordReset struct {
+	requestID string
+	email     string
+}
+
+// accountBusiness struct that implements AccountBusiness interface
+type accountBusiness struct {
+	UnitOfWork          db.UnitOfWork
+	UserService         services.UserService
+	RefreshTokenService services.RefreshTokenService
+	UserTokenService    services.UserTokenService
+	MailService         services.MailService
+	OneTimeCodeService  services.OneTimeCodeService
+	SmsService          services.SmsService
+	TwoFactorService    services.TwoFactorService
+	PermissionService   services.PermissionService
+
+	logger                  log.Logger
+	tokens                  *token.Manager
+	accessTokenTTL          time.Duration
+	refreshTokenTTL         time.Duration
+	verificationTokenTTL    time.Duration
+	verificationResendDelay time.Duration
+	otpResendDelay          time.Duration
+	passwordResetTokenTTL   time.Duration
+	twoFactorChallengeTTL   time.Duration
+	adminEmail              string
+	passwordResets          chan passwordReset
+}
+
+// AccountBusiness ensures interface implementation
+func (bl *accountBusiness) AccountBusiness() string {
+	return "accountBusiness"
+}
+
+// Register creates new user account with given credentials
+func (bl *accountBusiness) Register(ctx context.Context, firstName string, lastName string, email string, plainPassword string) (*models.User, error) {
+	_, err := bl.UserService.GetByEmail(ctx, email)
+	if err == nil {
+		return nil, ErrEmailTaken
+	}
+	if !gorm.IsRecordNotFoundError(err) {
+		return nil, err
+	}
+
+	hash, err := password.Hash(plainPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		FirstName:    firstName,
+		LastName:     lastName,
+		Email:        email,
+		PasswordHash: hash,
+		IsActive:     true,
+	}
+
+	err = bl.UnitOfWork.WithTransaction(ctx, func(ctx context.Context, _ db.Store) error {
+		if err := bl.UserService.Create(ctx, user); err != nil {
+			return err
+		}
+		return bl.PermissionService.AssignRole(ctx, user.ID, models.RoleUser)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// account is created even if verification email cannot be sent,
+	// user can request new verification email later
+	if err := bl.sendEmailVerification(ctx, user); err != nil {
+		bl.logger.Errorf("unable to send verification email to user %d: %v", user.ID, err)
+	}
+
+	return user, nil
+}
+
+// Login checks given credentials and issues access and refresh tokens
+//
+// when user has two-factor authentication enabled, challenge for second login step is returned instead of tokens
+func (bl *accountBusiness) Login(ctx context.Context, email string, plainPassword string) (*models.AuthTokens, *models.TwoFactorChallenge, error) {
+	user, err := bl.UserService.GetByEmail(ctx, email)
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return nil, nil, err
+	}
+
+	hash := ""
+	if err == nil {
+		hash = user.PasswordHash
+	}
+
+	if !password.Compare(hash, plainPassword) {
+		return nil, nil, ErrInvalidCredentials
+	}
+
+	// status is revealed only to callers who know the password
+	if err := checkStatus(user); err != nil {
+		return nil, nil, err
+	}
+
+	enabled, err := bl.TwoFactorService.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if enabled {
+		plain, challenge, err := bl.UserTokenService.Issue(ctx, user.ID, models.UserTokenPurposeTwoFactorLogin, bl.twoFactorChallengeTTL)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, &models.TwoFactorChallenge{
+			TwoFactorRequired: true,
+			ChallengeToken:    plain,
+			ExpiresAt:         challenge.ExpiresAt,
+		}, nil
+	}
+
+	tokens, _, err := bl.issueTokens(ctx, user)
+	return tokens, nil, err
+}
+
+// LoginTwoFactor completes login challenge with authentication or recovery code and issues access and refresh tokens
+//
+// challenge is single-use, after wrong code user has to start login again
+// which limits code guessing to one attempt per password check.
+// Challenge and code are login credentials, so their failures are reported as ErrInvalidTwoFactorLogin
+func (bl *accountBusiness) LoginTwoFactor(ctx context.Context, challengeToken string, code string) (*models.AuthTokens, error) {
+	challenge, err := bl.UserTokenService.Consume(ctx, models.UserTokenPurposeTwoFactorLogin, challengeToken)
+	if err == services.ErrInvalidUserToken {
+		return nil, ErrInvalidTwoFactorLogin
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = bl.TwoFactorService.Verify(ctx, challenge.UserID, code)
+	if err == services.ErrInvalidTwoFactorCode || err == services.ErrTwoFactorNotEnrolled {
+		return nil, ErrInvalidTwoFactorLogin
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := bl.UserService.GetByID(ctx, challenge.UserID)
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, ErrInvalidTwoFactorLogin
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkStatus(user); err != nil {
+		return nil, err
+	}
+
+	tokens, _, err := bl.issueTokens(ctx, user)
+	return tokens, err
+}
+
+// Refresh rotates given refresh token and issues new access and refresh tokens
+//
+// presenting already rotated token is treated as token theft
+// and revokes every refresh token issued to the user.
+// Token is rotated in transaction with conditional update, so of concurrent
+// requests presenting the same token only one is issued new tokens
+func (bl *accountBusiness) Refresh(ctx context.Context, refreshToken string) (*models.AuthTokens, error) {
+	current, err := bl.RefreshTokenService.GetByToken(ctx, refreshToken)
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if current.RevokedAt != nil && current.ReplacedByID != nil {
+		if err := bl.RefreshTokenService.RevokeAll(ctx, current.UserID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if !current.IsActive(time.Now()) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := bl.UserService.GetByID(ctx, current.UserID)
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkStatus(user); err != nil {
+		return nil, err
+	}
+
+	var tokens *models.AuthTokens
+
+	err = bl.UnitOfWork.WithTransaction(ctx, func(ctx context.Context, _ db.Store) error {
+		var (
+			next *models.RefreshToken
+			err  error
+		)
+
+		tokens, next, err = bl.issueTokens(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		rotated, err := bl.RefreshTokenService.Rotate(ctx, current, next.ID)
+		if err != nil {
+			return err
+		}
+		if !rotated {
+			return ErrInvalidRefreshToken
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// Logout revokes given refresh token, or all user refresh tokens when all is true
+func (bl *accountBusiness) Logout(ctx context.Context, userID uint64, refreshToken string, all bool) error {
+	if all {
+		return bl.RefreshTokenService.RevokeAll(ctx, userID)
+	}
+
+	current, err := bl.RefreshTokenService.GetByToken(ctx, refreshToken)
+	if gorm.IsRecordNotFoundError(err) {
+		return ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return err
+	}
+
+	if current.UserID != userID {
+		return ErrInvalidRefreshToken
+	}
+
+	return bl.RefreshTokenService.Revoke(ctx, current)
+}
+
+// Authenticate returns user model for given access token
+func (bl *accountBusiness) Authenticate(ctx context.Context, accessToken string) (*models.User, error) {
+	claims, err := bl.tokens.Parse(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return nil, token.ErrInvalidToken
+	}
+
+	user, err := bl.UserService.GetByID(ctx, userID)
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, token.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkStatus(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// SendEmailVerification sends email verification link to user with given id
+func (bl *accountBusiness) SendEmailVerification(ctx context.Context, userID uint64) error {
+	user, err := bl.UserService.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.IsEmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	latest, err := bl.UserTokenService.GetLatest(ctx, user.ID, models.UserTokenPurposeEmailVerification)
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return err
+	}
+	if err == nil && time.Since(latest.CreatedAt) < bl.verificationResendDelay {
+		return ErrThrottled
+	}
+
+	return bl.sendEmailVerification(ctx, user)
+}
+
+// VerifyEmail marks email of user who received given verification token as verified
+func (bl *accountBusiness) VerifyEmail(ctx context.Context, verificationToken string) (*models.User, error) {
+	userToken, err := bl.UserTokenService.Consume(ctx, models.UserTokenPurposeEmailVerification, verificationToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := bl.UserService.GetByID(ctx, userToken.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsEmailVerified {
+		return user, nil
+	}
+
+	user.IsEmailVerified = true
+
+	if err := bl.UserService.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, grantAdmin(ctx, bl.PermissionService, bl.logger, bl.adminEmail, user)
+}
+
+// SendPhoneVerification sends one time verification code to phone number of user with given id
+func (bl *accountBusiness) SendPhoneVerification(ctx context.Context, userID uint64) error {
+	user, err := bl.UserService.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.PhoneNumber == "" {
+		return ErrPhoneNumberMissing
+	}
+
+	if user.IsPhoneVerified {
+		return ErrPhoneAlreadyVerified
+	}
+
+	latest, err := bl.OneTimeCodeService.GetLatest(ctx, user.ID, models.OneTimeCodePurposePhoneVerification)
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return err
+	}
+	if err == nil && time.Since(latest.CreatedAt) < bl.otpResendDelay {
+		return ErrThrottled
+	}
+
+	plain, _, err := bl.OneTimeCodeService.Issue(ctx, user.ID, models.OneTimeCodePurposePhoneVerification, user.PhoneNumber)
+	if err != nil {
+		return err
+	}
+
+	return bl.SmsService.SendPhoneVerification(user.PhoneNumber, plain)
+}
+
+// VerifyPhone marks phone number of user with given id as verified using one time code
+//
+// code is accepted only for the number it was sent to,
+// changing phone number in the meantime invalidates the code
+func (bl *accountBusiness) VerifyPhone(ctx context.Context, userID uint64, code string) (*models.User, error) {
+	user, err := bl.UserService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsPhoneVerified {
+		return nil, ErrPhoneAlreadyVerified
+	}
+
+	otp, err := bl.OneTimeCodeService.Verify(ctx, user.ID, models.OneTimeCodePurposePhoneVerification, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if otp.Destination != user.PhoneNumber {
+		return nil, services.ErrInvalidOneTimeCode
+	}
+
+	user.IsPhoneVerified = true
+
+	return user, bl.UserService.Update(ctx, user)
+}
+
+// ForgotPassword queues sending of password reset link to user with given email
+//
+// account lookup, token issuing and delivery are done in background and their failures are only logged,
+// so known and unknown emails get the same response in the same time and caller cannot tell whether account exists
+func (bl *accountBusiness) ForgotPassword(ctx context.Context, email string) error {
+	select {
+	case bl.passwordResets <- passwordReset{requestID: db.RequestID(ctx), email: email}:
+	default:
+		bl.logger.Errorf("password reset queue is full, request %s is dropped", db.RequestID(ctx))
+	}
+
+	return nil
+}
+
+// processPasswordResets sends queued password reset links
+//
+// requests are processed without request context, so they are not canceled once response is sent
+func (bl *accountBusiness) processPasswordResets() {
+	for reset := range bl.passwordResets {
+		ctx := db.WithRequestID(context.Background(), reset.requestID)
+		if err := bl.sendPasswordReset(ctx, reset.email); err != nil {
+			bl.logger.Errorf("unable to process password reset request %s: %v", reset.requestID, err)
+		}
+	}
+}
+
+// sendPasswordReset issues password reset token and sends it to user with given email, unknown emails are ignored
+func (bl *accountBusiness) sendPasswordReset(ctx context.Context, email string) error {
+	user, err := bl.UserService.GetByEmail(ctx, email)
+	if gorm.IsRecordNotFoundError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	plain, _, err := bl.UserTokenService.Issue(ctx, user.ID, models.UserTokenPurposePasswordReset, bl.passwordResetTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	return bl.MailService.SendPasswordReset(user, plain)
+}
+
+// ResetPassword sets new password for user who received given reset token
+// and revokes all refresh tokens issued to the user
+func (bl *accountBusiness) ResetPassword(ctx context.Context, resetToken string, plainPassword string) error {
+	userToken, err := bl.UserTokenService.Consume(ctx, models.UserTokenPurposePasswordReset, resetToken)
+	if err != nil {
+		return err
+	}
+
+	user, err := bl.UserService.GetByID(ctx, userToken.UserID)
+	if gorm.IsRecordNotFoundError(err) {
+		return services.ErrInvalidUserToken
+	}
+	if err != nil {
+		return err
+	}
+
+	hash, err := password.Hash(plainPassword)
+	if err != nil {
+		return err
+	}
+
+	user.PasswordHash = hash
+	if err := bl.UserService.Update(ctx, user); err != nil {
+		return err
+	}
+
+	return bl.RefreshTokenService.RevokeAll(ctx, user.ID)
+}
+
+// EnrollTwoFactor starts two-factor enrollment for user with given id
+func (bl *accountBusiness) EnrollTwoFactor(ctx context.Context, userID uint64) (*models.TwoFactorEnrollment, error) {
+	user, err := bl.UserService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return bl.TwoFactorService.Enroll(ctx, user)
+}
+
+// ConfirmTwoFactor enables two-factor authentication and returns recovery codes
+func (bl *accountBusiness) ConfirmTwoFactor(ctx context.Context, userID uint64, code string) (*models.TwoFactorRecoveryCodes, error) {
+	codes, err := bl.TwoFactorService.Confirm(ctx, userID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TwoFactorRecoveryCodes{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// DisableTwoFactor disables two-factor authentication after checking authentication or recovery code
+func (bl *accountBusiness) DisableTwoFactor(ctx context.Context, userID uint64, code string) error {
+	if err := bl.TwoFactorService.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+
+	return bl.TwoFactorService.Disable(ctx, userID)
+}
+
+// sendEmailVerification issues new verification token and sends it to user
+func (bl *accountBusiness) sendEmailVerification(ctx context.Context, user *models.User) error {
+	plain, _, err := bl.UserTokenService.Issue(ctx, user.ID, models.UserTokenPurposeEmailVerification, bl.verificationTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	return bl.MailService.SendEmailVerification(user, plain)
+}
+
+// issueTokens creates access token and refresh token pair for given user
+func (bl *accountBusiness) issueTokens(ctx context.Context, user *models.User) (*models.AuthTokens, *models.RefreshToken, error) {
+	accessToken, accessExpiresAt, err := bl.tokens.Issue(user.ID, bl.accessTokenTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refreshToken, model, err := bl.RefreshTokenService.Issue(ctx, user.ID, bl.refreshTokenTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &models.AuthTokens{
+		TokenType:             "Bearer",
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: model.ExpiresAt,
+		User:                  user,
+	}, model, nil
+}
+
+// BootstrapAdmin grants admin role to already verified user with configured admin email
+//
+// it covers admin who verified email before admin email was configured,
+// nothing is done when admin email is not configured or such user does not exist
+func (bl *accountBusiness) BootstrapAdmin(ctx context.Context) error {
+	if bl.adminEmail == "" {
+		return nil
+	}
+
+	user, err := bl.UserService.GetByEmail(ctx, bl.adminEmail)
+	if gorm.IsRecordNotFoundError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return grantAdmin(ctx, bl.PermissionService, bl.logger, bl.adminEmail, user)
+}
+
+// grantAdmin assigns admin role to given user when user email is verified and matches admin email
+//
+// unverified email is not trusted, so account registered with admin email by someone else is not granted admin role
+func grantAdmin(ctx context.Context, permissions services.PermissionService, logger log.Logger, adminEmail string, user *models.User) error {
+	if adminEmail == "" || !user.IsEmailVerified || !strings.EqualFold(user.Email, adminEmail) {
+		return nil
+	}
+
+	if err := permissions.AssignRole(ctx, user.ID, models.RoleAdmin); err != nil {
+		return err
+	}
+
+	logger.Infof("user %d with configured admin email has `%s` role", user.ID, models.RoleAdmin)
+	return nil
+}
@@ -1,8 +1,23 @@
 package business
 
-import "github.com/go-flow/flow"
+import (
+	"context"
+
+	"github.com/go-flow/flow"
+)
 
 // Init initializes project business layer
 func Init(app *flow.App) {
 	app.Register(NewUserBusiness(app))
+
+	account := NewAccountBusiness(app)
+	app.Register(account)
+	app.Register(NewRoleBusiness(app))
+	app.Register(NewInvitationBusiness(app))
+	app.Register(NewTosBusiness(app))
+
+	// admin who verified email before admin email was configured is granted admin role on start
+	if err := account.BootstrapAdmin(context.Background()); err != nil {
+		app.Logger.Errorf("unable to bootstrap admin: %v", err)
+	}
 }
@@ -0,0 +1,178 @@
+package business
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/flow/log"
+	"github.com/go-flow/template-api/config"
+	"github.com/go-flow/template-api/db"
+	apperrors "github.com/go-flow/template-api/domain/errors"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/pkg/password"
+	"github.com/go-flow/template-api/services"
+	"github.com/jinzhu/gorm"
+)
+
+// ErrInvitationPending is returned when inviting email which already has pending invitation
+var ErrInvitationPending = apperrors.Conflict(apperrors.CodeInvitationPending, "email has already been invited")
+
+// InvitationBusiness defines set of business rules related to user invitations
+type InvitationBusiness interface {
+	// InvitationBusiness ensures interface implementation
+	InvitationBusiness() string
+
+	// Create invites given email on behalf of inviter and sends invitation email
+	Create(ctx context.Context, inviterUserID uint64, email string) (*models.Invitation, error)
+
+	// GetAllByInviter returns all invitations sent by given user
+	GetAllByInviter(ctx context.Context, inviterUserID uint64) ([]*models.Invitation, error)
+
+	// Revoke cancels pending invitation sent by given user
+	Revoke(ctx context.Context, inviterUserID uint64, invitationID uint64) (*models.Invitation, error)
+
+	// Accept creates user account for invitation with given token
+	Accept(ctx context.Context, invitationToken string, firstName string, lastName string, plainPassword string) (*models.User, error)
+}
+
+// NewInvitationBusiness creates new invitation business rules implementation instance
+func NewInvitationBusiness(app *flow.App) InvitationBusiness {
+	cfg := app.AppConfig.(config.AppConfig)
+
+	return &invitationBusiness{
+		logger:        app.Logger,
+		invitationTTL: time.Hour * time.Duration(cfg.InvitationTTL),
+		adminEmail:    cfg.AdminEmail,
+	}
+}
+
+// invitationBusiness struct that implements InvitationBusiness interface
+type invitationBusiness struct {
+	UnitOfWork        db.UnitOfWork
+	UserService       services.UserService
+	InvitationService services.InvitationService
+	MailService       services.MailService
+	PermissionService services.PermissionService
+
+	logger        log.Logger
+	invitationTTL time.Duration
+	adminEmail    string
+}
+
+// InvitationBusiness ensures interface implementation
+func (bl *invitationBusiness) InvitationBusiness() string {
+	return "invitationBusiness"
+}
+
+// Create invites given email on behalf of inviter and sends invitation email
+//
+// invitation which cannot be delivered is revoked, so inviter can try again
+func (bl *invitationBusiness) Create(ctx context.Context, inviterUserID uint64, email string) (*models.Invitation, error) {
+	inviter, err := bl.UserService.GetByID(ctx, inviterUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = bl.UserService.GetByEmail(ctx, email)
+	if err == nil {
+		return nil, ErrEmailTaken
+	}
+	if !gorm.IsRecordNotFoundError(err) {
+		return nil, err
+	}
+
+	pending, err := bl.InvitationService.HasPending(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, ErrInvitationPending
+	}
+
+	plain, invitation, err := bl.InvitationService.Create(ctx, inviter.ID, email, bl.invitationTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bl.MailService.SendInvitation(inviter, invitation, plain); err != nil {
+		if revokeErr := bl.InvitationService.Revoke(ctx, invitation); revokeErr != nil {
+			bl.logger.Errorf("unable to revoke undelivered invitation %d: %v", invitation.ID, revokeErr)
+		}
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// GetAllByInviter returns all invitations sent by given user
+func (bl *invitationBusiness) GetAllByInviter(ctx context.Context, inviterUserID uint64) ([]*models.Invitation, error) {
+	return bl.InvitationService.GetAllByInviter(ctx, inviterUserID)
+}
+
+// Revoke cancels pending invitation sent by given user
+//
+// invitations sent by other users are reported as not found
+func (bl *invitationBusiness) Revoke(ctx context.Context, inviterUserID uint64, invitationID uint64) (*models.Invitation, error) {
+	invitation, err := bl.InvitationService.GetByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if invitation.InviterUserID != inviterUserID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return invitation, bl.InvitationService.Revoke(ctx, invitation)
+}
+
+// Accept creates user account for invitation with given token
+//
+// invitation link proves ownership of the email, so new account email is verified
+func (bl *invitationBusiness) Accept(ctx context.Context, invitationToken string, firstName string, lastName string, plainPassword string) (*models.User, error) {
+	invitation, err := bl.InvitationService.GetByToken(ctx, invitationToken)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = bl.UserService.GetByEmail(ctx, invitation.Email)
+	if err == nil {
+		return nil, ErrEmailTaken
+	}
+	if !gorm.IsRecordNotFoundError(err) {
+		return nil, err
+	}
+
+	hash, err := password.Hash(plainPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		FirstName:       firstName,
+		LastName:        lastName,
+		Email:           invitation.Email,
+		PasswordHash:    hash,
+		IsEmailVerified: true,
+		InvitedByUserID: &invitation.InviterUserID,
+		IsActive:        true,
+	}
+
+	err = bl.UnitOfWork.WithTransaction(ctx, func(ctx context.Context, _ db.Store) error {
+		if err := bl.UserService.Create(ctx, user); err != nil {
+			return err
+		}
+		if err := bl.PermissionService.AssignRole(ctx, user.ID, models.RoleUser); err != nil {
+			return err
+		}
+		if err := bl.InvitationService.Accept(ctx, invitation, user.ID); err != nil {
+			return err
+		}
+		return grantAdmin(ctx, bl.PermissionService, bl.logger, bl.adminEmail, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,82 @@
+package business
+
+import (
+	"context"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/services"
+)
+
+// RoleBusiness defines set of business rules related to user roles and permissions
+type RoleBusiness interface {
+	// RoleBusiness ensures interface implementation
+	RoleBusiness() string
+
+	// HasPermission checks if user is granted given permission
+	HasPermission(ctx context.Context, userID uint64, permission string) (bool, error)
+
+	// GetUserRoles returns all roles assigned to user with given id
+	GetUserRoles(ctx context.Context, userID uint64) ([]*models.Role, error)
+
+	// AssignRole assigns role with given name to user with given id
+	AssignRole(ctx context.Context, userID uint64, roleName string) ([]*models.Role, error)
+
+	// RevokeRole removes role with given name from user with given id
+	RevokeRole(ctx context.Context, userID uint64, roleName string) ([]*models.Role, error)
+}
+
+// NewRoleBusiness creates new role business rules implementation instance
+func NewRoleBusiness(app *flow.App) RoleBusiness {
+	return &roleBusiness{}
+}
+
+// roleBusiness struct that implements RoleBusiness interface
+type roleBusiness struct {
+	UserService       services.UserService
+	PermissionService services.PermissionService
+}
+
+// RoleBusiness ensures interface implementation
+func (bl *roleBusiness) RoleBusiness() string {
+	return "roleBusiness"
+}
+
+// HasPermission checks if user is granted given permission
+func (bl *roleBusiness) HasPermission(ctx context.Context, userID uint64, permission string) (bool, error) {
+	return bl.PermissionService.HasPermission(ctx, userID, permission)
+}
+
+// GetUserRoles returns all roles assigned to user with given id
+func (bl *roleBusiness) GetUserRoles(ctx context.Context, userID uint64) ([]*models.Role, error) {
+	if _, err := bl.UserService.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return bl.PermissionService.GetRoles(ctx, userID)
+}
+
+// AssignRole assigns role with given name to user with given id
+func (bl *roleBusiness) AssignRole(ctx context.Context, userID uint64, roleName string) ([]*models.Role, error) {
+	if _, err := bl.UserService.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := bl.PermissionService.AssignRole(ctx, userID, roleName); err != nil {
+		return nil, err
+	}
+
+	return bl.PermissionService.GetRoles(ctx, userID)
+}
+
+// RevokeRole removes role with given name from user with given id
+func (bl *roleBusiness) RevokeRole(ctx context.Context, userID uint64, roleName string) ([]*models.Role, error) {
+	if _, err := bl.UserService.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := bl.PermissionService.RevokeRole(ctx, userID, roleName); err != nil {
+		return nil, err
+	}
+
+	return bl.PermissionService.GetRoles(ctx, userID)
+}
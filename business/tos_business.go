@@ -0,0 +1,126 @@
+package business
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	apperrors "github.com/go-flow/template-api/domain/errors"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/services"
+)
+
+// ErrTosVersionOutdated is returned when accepting terms of service version which is not current
+var ErrTosVersionOutdated = apperrors.Conflict(apperrors.CodeTosVersionOutdated, "terms of service version is not current")
+
+// TosBusiness defines set of business rules related to terms of service
+type TosBusiness interface {
+	// TosBusiness ensures interface implementation
+	TosBusiness() string
+
+	// GetCurrent returns current terms of service version
+	GetCurrent(ctx context.Context) (*models.TosVersion, error)
+
+	// Publish creates new terms of service version, it becomes current at publishedAt or immediately when nil
+	Publish(ctx context.Context, version string, summary string, url string, publishedAt *time.Time) (*models.TosVersion, error)
+
+	// Accept records user accepting current terms of service version
+	Accept(ctx context.Context, userID uint64, tosVersionID uint64, ipAddress string, userAgent string) (*models.UserTosAcceptance, error)
+
+	// HasAcceptedCurrentTos reports whether user accepted current terms of service version
+	HasAcceptedCurrentTos(ctx context.Context, userID uint64) (bool, error)
+
+	// GetAcceptances returns terms of service acceptance history of given user
+	GetAcceptances(ctx context.Context, userID uint64) ([]*models.UserTosAcceptance, error)
+}
+
+// NewTosBusiness creates new terms of service business rules implementation instance
+func NewTosBusiness(app *flow.App) TosBusiness {
+	return &tosBusiness{}
+}
+
+// tosBusiness struct that implements TosBusiness interface
+type tosBusiness struct {
+	UserService services.UserService
+	TosService  services.TosService
+}
+
+// TosBusiness ensures interface implementation
+func (bl *tosBusiness) TosBusiness() string {
+	return "tosBusiness"
+}
+
+// GetCurrent returns current terms of service version
+func (bl *tosBusiness) GetCurrent(ctx context.Context) (*models.TosVersion, error) {
+	return bl.TosService.GetCurrent(ctx)
+}
+
+// Publish creates new terms of service version, it becomes current at publishedAt or immediately when nil
+func (bl *tosBusiness) Publish(ctx context.Context, version string, summary string, url string, publishedAt *time.Time) (*models.TosVersion, error) {
+	model := &models.TosVersion{
+		Version: version,
+		Summary: summary,
+		URL:     url,
+	}
+
+	if publishedAt != nil {
+		model.PublishedAt = *publishedAt
+	}
+
+	return model, bl.TosService.Publish(ctx, model)
+}
+
+// Accept records user accepting current terms of service version
+//
+// client sends version it displayed to the user, so acceptance
+// of version published in the meantime is not recorded by mistake
+func (bl *tosBusiness) Accept(ctx context.Context, userID uint64, tosVersionID uint64, ipAddress string, userAgent string) (*models.UserTosAcceptance, error) {
+	current, err := bl.TosService.GetCurrent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.ID != tosVersionID {
+		return nil, ErrTosVersionOutdated
+	}
+
+	acceptance, err := bl.TosService.Accept(ctx, userID, current.ID, ipAddress, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	acceptance.TosVersion = current
+
+	user, err := bl.UserService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TosAccepted {
+		user.TosAccepted = true
+		if err := bl.UserService.Update(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	return acceptance, nil
+}
+
+// HasAcceptedCurrentTos reports whether user accepted current terms of service version
+//
+// when no version has been published there is nothing to accept
+func (bl *tosBusiness) HasAcceptedCurrentTos(ctx context.Context, userID uint64) (bool, error) {
+	current, err := bl.TosService.GetCurrent(ctx)
+	if err == services.ErrNoTosVersion {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return bl.TosService.HasAccepted(ctx, userID, current.ID)
+}
+
+// GetAcceptances returns terms of service acceptance history of given user
+func (bl *tosBusiness) GetAcceptances(ctx context.Context, userID uint64) ([]*models.UserTosAcceptance, error) {
+	return bl.TosService.GetAcceptances(ctx, userID)
+}
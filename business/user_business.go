@@ -1,46 +1,127 @@
 package business
 
 import (
+	"context"
+	"strings"
 	"time"
 
 	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/config"
+	"github.com/go-flow/template-api/db"
+	apperrors "github.com/go-flow/template-api/domain/errors"
 	"github.com/go-flow/template-api/domain/models"
 	"github.com/go-flow/template-api/pkg/paging"
+	"github.com/go-flow/template-api/pkg/phone"
 	"github.com/go-flow/template-api/services"
 )
 
+var (
+	// ErrInvalidPhoneNumber is returned when user phone number cannot be normalized to E.164 format
+	ErrInvalidPhoneNumber = apperrors.Validation(apperrors.CodeInvalidPhoneNumber, "invalid phone number")
+
+	// ErrInvalidStatusTransition is returned when user account status cannot change as requested from its current status
+	ErrInvalidStatusTransition = apperrors.Conflict(apperrors.CodeInvalidStatusTransition, "invalid account status transition")
+
+	// ErrOwnStatusChange is returned when user tries to change status of own account
+	ErrOwnStatusChange = apperrors.Forbidden(apperrors.CodeOwnStatusChange, "cannot change status of own account")
+
+	// ErrInvalidSuspensionExpiry is returned when suspension expiry is not in the future
+	ErrInvalidSuspensionExpiry = apperrors.Validation(apperrors.CodeInvalidSuspensionExpiry, "suspension expiry must be in the future")
+
+	// ErrEmptySearchQuery is returned when search query has no searchable content
+	ErrEmptySearchQuery = apperrors.Validation(apperrors.CodeEmptySearchQuery, "search query is required")
+)
+
+const (
+	// defaultSearchLimit is number of search results returned when limit is not provided
+	defaultSearchLimit = 20
+
+	// maxSearchLimit is maximal number of search results returned at once
+	maxSearchLimit = 100
+)
+
 // UserBusiness defines set of business rules related to Users model
 type UserBusiness interface {
 	// UserBusiness ensures interface implementation
 	UserBusiness() string
 
 	// GetByID returns user model for provided id
-	GetByID(id uint64) (*models.User, error)
+	GetByID(ctx context.Context, id uint64) (*models.User, error)
+
+	// GetByIDWithFields returns user model for provided id with only fieldset fields loaded
+	GetByIDWithFields(ctx context.Context, id uint64, fields paging.Fields) (*models.User, error)
 
 	// GetByEmail returns user model for provided email
-	GetByEmail(email string) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
 
 	// GetAll returns all Users model for given paging users
-	GetAll(paginator *paging.Paginator) ([]*models.User, error)
+	GetAll(ctx context.Context, paginator *paging.Paginator) ([]*models.User, error)
+
+	// Search returns users matching full-text query, ordered by relevance
+	//
+	// private fields are matched only when private is true
+	Search(ctx context.Context, query string, limit int, private bool) ([]*models.UserSearchResult, error)
 
 	// Create new User record in database
-	Create(firstName *string, lastName *string, profileImage *string, birthDate *time.Time, bio *string, phoneNumber *string, country *string, state *string, area *string, city *string, address *string, postCode *string) (*models.User, error)
+	Create(ctx context.Context, email string, firstName *string, lastName *string, profileImage *string, birthDate *time.Time, bio *string, phoneNumber *string, country *string, state *string, area *string, city *string, address *string, postCode *string) (*models.User, error)
 
 	// Update User record in database
-	Update(userID uint64, firstName *string, lastName *string, profileImage *string, birthDate *time.Time, bio *string, phoneNumber *string, country *string, state *string, area *string, city *string, address *string, postCode *string) (*models.User, error)
+	//
+	// nil fields are left unchanged, zero birth date clears it
+	Update(ctx context.Context, userID uint64, firstName *string, lastName *string, profileImage *string, birthDate *time.Time, bio *string, phoneNumber *string, country *string, state *string, area *string, city *string, address *string, postCode *string) (*models.User, error)
+
+	// Delete soft deletes User object
+	Delete(ctx context.Context, id uint64) error
+
+	// Restore clears deletion of soft deleted user
+	Restore(ctx context.Context, id uint64) (*models.User, error)
+
+	// Purge permanently deletes user, whether it is soft deleted or not
+	Purge(ctx context.Context, id uint64) error
 
-	// Delete User object from database
-	Delete(id uint64) error
+	// PurgeDeleted permanently deletes users soft deleted longer than configured retention period
+	PurgeDeleted(ctx context.Context) (int64, error)
+
+	// GetInvitees returns tree of users invited by given user, walking at most depth levels
+	GetInvitees(ctx context.Context, userID uint64, depth int) ([]*models.Invitee, error)
+
+	// Activate activates inactive user account
+	Activate(ctx context.Context, actorUserID uint64, userID uint64) (*models.User, error)
+
+	// Deactivate deactivates active or suspended user account
+	Deactivate(ctx context.Context, actorUserID uint64, userID uint64, reason string) (*models.User, error)
+
+	// Suspend suspends active user account with given reason until given time, or until reactivation when until is nil
+	Suspend(ctx context.Context, actorUserID uint64, userID uint64, reason string, until *time.Time) (*models.User, error)
+
+	// Reactivate lifts suspension of user account
+	Reactivate(ctx context.Context, actorUserID uint64, userID uint64) (*models.User, error)
+
+	// GetStatusTransitions returns account status history of given user, newest first
+	GetStatusTransitions(ctx context.Context, userID uint64) ([]*models.UserStatusTransition, error)
 }
 
 // NewUserBusiness creates new users business rules implementation instance
 func NewUserBusiness(app *flow.App) UserBusiness {
-	return &userBusiness{}
+	cfg := app.AppConfig.(config.AppConfig)
+
+	return &userBusiness{
+		phoneDefaultRegion:     cfg.PhoneDefaultRegion,
+		invitationTreeMaxDepth: cfg.InvitationTreeMaxDepth,
+		purgeRetention:         time.Duration(cfg.UserPurgeRetention) * 24 * time.Hour,
+	}
 }
 
 // userBusiness struct that implements UserBusiness interface
 type userBusiness struct {
-	UserService services.UserService
+	UserService                 services.UserService
+	UserStatusTransitionService services.UserStatusTransitionService
+	PermissionService           services.PermissionService
+	UnitOfWork                  db.UnitOfWork
+
+	phoneDefaultRegion     string
+	invitationTreeMaxDepth int
+	purgeRetention         time.Duration
 }
 
 // UserBusiness ensures interface implementation
@@ -49,23 +130,48 @@ func (bl *userBusiness) UserBusiness() string {
 }
 
 // GetByID returns user model for provided id
-func (bl *userBusiness) GetByID(id uint64) (*models.User, error) {
-	return bl.UserService.GetByID(id)
+func (bl *userBusiness) GetByID(ctx context.Context, id uint64) (*models.User, error) {
+	return bl.UserService.GetByID(ctx, id)
+}
+
+// GetByIDWithFields returns user model for provided id with only fieldset fields loaded
+func (bl *userBusiness) GetByIDWithFields(ctx context.Context, id uint64, fields paging.Fields) (*models.User, error) {
+	return bl.UserService.GetByIDWithFields(ctx, id, fields)
 }
 
 // GetByEmail returns user model for provided email
-func (bl *userBusiness) GetByEmail(email string) (*models.User, error) {
-	return bl.UserService.GetByEmail(email)
+func (bl *userBusiness) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	return bl.UserService.GetByEmail(ctx, email)
 }
 
 // GetAll returns all Users model for given paging users
-func (bl *userBusiness) GetAll(paginator *paging.Paginator) ([]*models.User, error) {
-	return bl.UserService.GetAll(paginator)
+func (bl *userBusiness) GetAll(ctx context.Context, paginator *paging.Paginator) ([]*models.User, error) {
+	return bl.UserService.GetAll(ctx, paginator)
+}
+
+// Search returns users matching full-text query, ordered by relevance
+//
+// limit defaults to 20 and is capped at 100 results
+func (bl *userBusiness) Search(ctx context.Context, query string, limit int, private bool) ([]*models.UserSearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	return bl.UserService.Search(ctx, query, limit, private)
 }
 
 // Create new User object in database
-func (bl *userBusiness) Create(firstName *string, lastName *string, profileImage *string, birthDate *time.Time, bio *string, phoneNumber *string, country *string, state *string, area *string, city *string, address *string, postCode *string) (*models.User, error) {
+func (bl *userBusiness) Create(ctx context.Context, email string, firstName *string, lastName *string, profileImage *string, birthDate *time.Time, bio *string, phoneNumber *string, country *string, state *string, area *string, city *string, address *string, postCode *string) (*models.User, error) {
 	user := new(models.User)
+	user.Email = email
 
 	if firstName != nil {
 		user.FirstName = *firstName
@@ -82,12 +188,14 @@ func (bl *userBusiness) Create(firstName *string, lastName *string, profileImage
 	if bio != nil {
 		user.Bio = *bio
 	}
-	if phoneNumber != nil {
-		user.PhoneNumber = *phoneNumber
-	}
 	if country != nil {
 		user.Country = *country
 	}
+	if phoneNumber != nil {
+		if err := bl.setPhoneNumber(user, *phoneNumber); err != nil {
+			return nil, err
+		}
+	}
 	if state != nil {
 		user.State = *state
 	}
@@ -108,12 +216,22 @@ func (bl *userBusiness) Create(firstName *string, lastName *string, profileImage
 
 	user.BirthDate = birthDate
 
-	return user, bl.UserService.Create(user)
+	err := bl.UnitOfWork.WithTransaction(ctx, func(ctx context.Context, _ db.Store) error {
+		if err := bl.UserService.Create(ctx, user); err != nil {
+			return err
+		}
+		return bl.PermissionService.AssignRole(ctx, user.ID, models.RoleUser)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
 }
 
 // Update existing User object in database
-func (bl *userBusiness) Update(userID uint64, firstName *string, lastName *string, profileImage *string, birthDate *time.Time, bio *string, phoneNumber *string, country *string, state *string, area *string, city *string, address *string, postCode *string) (*models.User, error) {
-	user, err := bl.GetByID(userID)
+func (bl *userBusiness) Update(ctx context.Context, userID uint64, firstName *string, lastName *string, profileImage *string, birthDate *time.Time, bio *string, phoneNumber *string, country *string, state *string, area *string, city *string, address *string, postCode *string) (*models.User, error) {
+	user, err := bl.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -133,12 +251,14 @@ func (bl *userBusiness) Update(userID uint64, firstName *string, lastName *strin
 	if bio != nil {
 		user.Bio = *bio
 	}
-	if phoneNumber != nil {
-		user.PhoneNumber = *phoneNumber
-	}
 	if country != nil {
 		user.Country = *country
 	}
+	if phoneNumber != nil {
+		if err := bl.setPhoneNumber(user, *phoneNumber); err != nil {
+			return nil, err
+		}
+	}
 	if state != nil {
 		user.State = *state
 	}
@@ -157,16 +277,262 @@ func (bl *userBusiness) Update(userID uint64, firstName *string, lastName *strin
 		user.PostCode = *postCode
 	}
 
-	user.BirthDate = birthDate
+	if birthDate != nil {
+		user.BirthDate = birthDate
 
-	return user, bl.UserService.Update(user)
+		// zero birth date clears it
+		if birthDate.IsZero() {
+			user.BirthDate = nil
+		}
+	}
+
+	return user, bl.UserService.Update(ctx, user)
 }
 
-// Delete User object from database
-func (bl *userBusiness) Delete(id uint64) error {
-	user, err := bl.GetByID(id)
-	if err != nil {
+// Delete soft deletes User object
+func (bl *userBusiness) Delete(ctx context.Context, id uint64) error {
+	return bl.UnitOfWork.WithTransaction(ctx, func(ctx context.Context, _ db.Store) error {
+		user, err := bl.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		return bl.UserService.Delete(ctx, user)
+	})
+}
+
+// Restore clears deletion of soft deleted user
+func (bl *userBusiness) Restore(ctx context.Context, id uint64) (*models.User, error) {
+	var user *models.User
+
+	err := bl.UnitOfWork.WithTransaction(ctx, func(ctx context.Context, _ db.Store) error {
+		if err := bl.UserService.Restore(ctx, id); err != nil {
+			return err
+		}
+
+		var err error
+		user, err = bl.GetByID(ctx, id)
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	return bl.UserService.Delete(user)
+
+	return user, nil
+}
+
+// Purge permanently deletes user, whether it is soft deleted or not
+func (bl *userBusiness) Purge(ctx context.Context, id uint64) error {
+	return bl.UserService.Purge(ctx, id)
+}
+
+// PurgeDeleted permanently deletes users soft deleted longer than configured retention period
+func (bl *userBusiness) PurgeDeleted(ctx context.Context) (int64, error) {
+	return bl.UserService.PurgeDeletedBefore(ctx, time.Now().Add(-bl.purgeRetention))
+}
+
+// GetInvitees returns tree of users invited by given user, walking at most depth levels
+//
+// tree is loaded level by level, one query per level, depth is capped by configuration
+func (bl *userBusiness) GetInvitees(ctx context.Context, userID uint64, depth int) ([]*models.Invitee, error) {
+	if _, err := bl.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if depth < 1 {
+		depth = 1
+	}
+	if depth > bl.invitationTreeMaxDepth {
+		depth = bl.invitationTreeMaxDepth
+	}
+
+	root := &models.Invitee{Invitees: make([]*models.Invitee, 0)}
+	level := map[uint64]*models.Invitee{userID: root}
+	visited := map[uint64]bool{userID: true}
+
+	for i := 0; i < depth && len(level) > 0; i++ {
+		ids := make([]uint64, 0, len(level))
+		for id := range level {
+			ids = append(ids, id)
+		}
+
+		users, err := bl.UserService.GetInvitees(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		next := make(map[uint64]*models.Invitee)
+		for _, user := range users {
+			if visited[user.ID] {
+				continue
+			}
+			visited[user.ID] = true
+
+			node := &models.Invitee{User: user, Invitees: make([]*models.Invitee, 0)}
+			parent := level[*user.InvitedByUserID]
+			parent.Invitees = append(parent.Invitees, node)
+			next[user.ID] = node
+		}
+		level = next
+	}
+
+	return root.Invitees, nil
+}
+
+// Activate activates inactive user account
+func (bl *userBusiness) Activate(ctx context.Context, actorUserID uint64, userID uint64) (*models.User, error) {
+	return bl.changeStatus(ctx, actorUserID, userID, "", func(user *models.User, from string) error {
+		if from != models.UserStatusInactive {
+			return ErrInvalidStatusTransition
+		}
+
+		user.IsActive = true
+		return nil
+	})
+}
+
+// Deactivate deactivates active or suspended user account
+//
+// suspension is lifted as well, so account stays inactive until activated again
+func (bl *userBusiness) Deactivate(ctx context.Context, actorUserID uint64, userID uint64, reason string) (*models.User, error) {
+	return bl.changeStatus(ctx, actorUserID, userID, reason, func(user *models.User, from string) error {
+		if from == models.UserStatusInactive {
+			return ErrInvalidStatusTransition
+		}
+
+		user.IsActive = false
+		clearSuspension(user)
+		return nil
+	})
+}
+
+// Suspend suspends active user account with given reason until given time, or until reactivation when until is nil
+//
+// suspending already suspended account replaces its reason and expiry,
+// account becomes active again without recorded transition once suspension expires
+func (bl *userBusiness) Suspend(ctx context.Context, actorUserID uint64, userID uint64, reason string, until *time.Time) (*models.User, error) {
+	now := time.Now()
+	if until != nil && !until.After(now) {
+		return nil, ErrInvalidSuspensionExpiry
+	}
+
+	return bl.changeStatus(ctx, actorUserID, userID, reason, func(user *models.User, from string) error {
+		if from == models.UserStatusInactive {
+			return ErrInvalidStatusTransition
+		}
+
+		user.SuspendedAt = &now
+		user.SuspendedUntil = until
+		user.SuspendedReason = reason
+		return nil
+	})
+}
+
+// Reactivate lifts suspension of user account
+func (bl *userBusiness) Reactivate(ctx context.Context, actorUserID uint64, userID uint64) (*models.User, error) {
+	return bl.changeStatus(ctx, actorUserID, userID, "", func(user *models.User, from string) error {
+		if from != models.UserStatusSuspended {
+			return ErrInvalidStatusTransition
+		}
+
+		user.IsActive = true
+		clearSuspension(user)
+		return nil
+	})
+}
+
+// GetStatusTransitions returns account status history of given user, newest first
+func (bl *userBusiness) GetStatusTransitions(ctx context.Context, userID uint64) ([]*models.UserStatusTransition, error) {
+	if _, err := bl.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return bl.UserStatusTransitionService.GetAllByUserID(ctx, userID)
+}
+
+// changeStatus applies given status change to user and records transition performed by actor
+//
+// user is updated and transition recorded in single transaction, so status never changes without history
+func (bl *userBusiness) changeStatus(ctx context.Context, actorUserID uint64, userID uint64, reason string, apply func(user *models.User, from string) error) (*models.User, error) {
+	if actorUserID == userID {
+		return nil, ErrOwnStatusChange
+	}
+
+	var user *models.User
+
+	err := bl.UnitOfWork.WithTransaction(ctx, func(ctx context.Context, _ db.Store) error {
+		var err error
+		user, err = bl.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		from := user.Status(now)
+		if err := apply(user, from); err != nil {
+			return err
+		}
+
+		if err := bl.UserService.Update(ctx, user); err != nil {
+			return err
+		}
+
+		transition := &models.UserStatusTransition{
+			UserID:      user.ID,
+			ActorUserID: &actorUserID,
+			FromStatus:  from,
+			ToStatus:    user.Status(now),
+			Reason:      reason,
+		}
+		if transition.ToStatus == models.UserStatusSuspended {
+			transition.ExpiresAt = user.SuspendedUntil
+		}
+
+		return bl.UserStatusTransitionService.Record(ctx, transition)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// clearSuspension removes suspension details from user
+func clearSuspension(user *models.User) {
+	user.SuspendedAt = nil
+	user.SuspendedUntil = nil
+	user.SuspendedReason = ""
+}
+
+// checkStatus returns error when user account cannot authenticate because it is inactive or suspended
+func checkStatus(user *models.User) error {
+	switch user.Status(time.Now()) {
+	case models.UserStatusSuspended:
+		return ErrAccountSuspended
+	case models.UserStatusInactive:
+		return ErrAccountInactive
+	}
+	return nil
+}
+
+// setPhoneNumber normalizes given phone number to E.164 format and assigns it to user
+//
+// national numbers are parsed using user country when it is ISO 3166-1 alpha-2 code,
+// otherwise configured default region is used. Changing the number resets its verification.
+func (bl *userBusiness) setPhoneNumber(user *models.User, phoneNumber string) error {
+	region := bl.phoneDefaultRegion
+	if len(user.Country) == 2 {
+		region = user.Country
+	}
+
+	normalized, err := phone.NormalizeE164(phoneNumber, region)
+	if err != nil {
+		return ErrInvalidPhoneNumber
+	}
+
+	if normalized != user.PhoneNumber {
+		user.PhoneNumber = normalized
+		user.IsPhoneVerified = false
+	}
+
+	return nil
 }
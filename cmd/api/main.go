@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-flow/template-api/business"
 	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/jobs"
 	"github.com/go-flow/template-api/repositories"
 	"github.com/go-flow/template-api/services"
 
@@ -33,6 +34,10 @@ var (
 // @license.url http://www.apache.org/licenses/LICENSE-2.0.html
 
 // @BasePath /
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 // @query.collection.format multi
 func main() {
 
@@ -57,6 +62,9 @@ func main() {
 	// initialize controllers
 	controllers.Init(app)
 
+	// start background jobs
+	jobs.Init(app)
+
 	app.Logger.Infof("Starting Template API Version: %s", Version)
 	if err := app.Serve(); err != nil && err != http.ErrServerClosed {
 		app.Logger.Error(err)
@@ -4,13 +4,18 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/go-flow/flow"
 )
 
+// developmentSecret is used for secrets which are not configured outside of production
+const developmentSecret = "secret"
+
 type dbConnection struct {
 	DbDialect         string
 	DbConnection      string
+	DbReplicas        []string
 	DbMaxIdleConns    int
 	DbMaxOpenConns    int
 	DbConnMaxLifetime int
@@ -22,6 +27,67 @@ type AppConfig struct {
 	DBConnections       map[string]dbConnection
 	DbMigrationsPath    string
 	DbMigrationsAutorun bool
+	DbRequestTimeout    int
+	DbTxMaxRetries      int
+	DbTxRetryBackoff    int
+
+	DbReplicaBalancer       string
+	DbReplicaHealthInterval int
+
+	JwtSigningMethod   string
+	JwtSecret          string
+	JwtPrivateKeyPath  string
+	JwtPublicKeyPath   string
+	JwtIssuer          string
+	JwtAccessTokenTTL  int
+	JwtRefreshTokenTTL int
+
+	AppURL string
+
+	MailDriver   string
+	MailFrom     string
+	MailFileDir  string
+	SmtpHost     string
+	SmtpPort     int
+	SmtpUsername string
+	SmtpPassword string
+
+	EmailVerificationTokenTTL       int
+	EmailVerificationResendInterval int
+
+	PasswordResetTokenTTL int
+
+	SmsDriver          string
+	PhoneDefaultRegion string
+
+	OtpHashKey        string
+	OtpLength         int
+	OtpTTL            int
+	OtpMaxAttempts    int
+	OtpResendInterval int
+
+	TwoFactorEncryptionKey string
+	TwoFactorIssuer        string
+	TwoFactorRecoveryCodes int
+	TwoFactorChallengeTTL  int
+
+	InvitationTTL          int
+	InvitationTreeMaxDepth int
+
+	AdminEmail string
+
+	UserPurgeRetention int
+	UserPurgeInterval  int
+
+	PagingCursorSecret string
+
+	SearchDriver string
+
+	ErrorFormat        string
+	ProblemTypeBaseURI string
+
+	LocalesPath     string
+	DefaultLanguage string
 }
 
 // Load application configuration
@@ -32,27 +98,34 @@ func Load() flow.Options {
 
 // LoadWithVersion loads application configuration with build version
 func LoadWithVersion(version string) flow.Options {
+	env := getEnv("ENV", "development")
+	production := env == "production"
+	jwtSigningMethod := getEnv("JWT_SIGNING_METHOD", "HS256")
+
 	cfg := AppConfig{
 		DBConnections: map[string]dbConnection{
 			"development": {
-				DbDialect:         "mysql",
+				DbDialect:         getEnv("DB_DEV_DIALECT", "mysql"), // mysql, postgres or sqlite3
 				DbConnection:      getEnv("DB_DEV_CONNECTION", "root:root@(localhost:3306)/flow_dev?multiStatements=true&readTimeout=1800s&charset=utf8mb4&parseTime=True&loc=Local"),
+				DbReplicas:        getEnvList("DB_DEV_REPLICA_CONNECTIONS"),
 				DbMaxIdleConns:    10,
 				DbMaxOpenConns:    100,
 				DbConnMaxLifetime: 30, // minutes
 				DbLogging:         true,
 			},
 			"test": {
-				DbDialect:         "mysql",
+				DbDialect:         getEnv("DB_TEST_DIALECT", "mysql"), // mysql, postgres or sqlite3
 				DbConnection:      getEnv("DB_TEST_CONNECTION", "root:root@(localhost:3306)/flow_dev?multiStatements=true&readTimeout=1800s&charset=utf8mb4&parseTime=True&loc=Local"),
+				DbReplicas:        getEnvList("DB_TEST_REPLICA_CONNECTIONS"),
 				DbMaxIdleConns:    10,
 				DbMaxOpenConns:    100,
 				DbConnMaxLifetime: 30, // minutes
 				DbLogging:         true,
 			},
 			"production": {
-				DbDialect:         "mysql",
+				DbDialect:         getEnv("DB_PROD_DIALECT", "mysql"), // mysql, postgres or sqlite3
 				DbConnection:      getEnv("DB_PROD_CONNECTION", "root:root@(localhost:3306)/flow_dev?multiStatements=true&readTimeout=1800s&charset=utf8mb4&parseTime=True&loc=Local"),
+				DbReplicas:        getEnvList("DB_PROD_REPLICA_CONNECTIONS"),
 				DbMaxIdleConns:    10,
 				DbMaxOpenConns:    100,
 				DbConnMaxLifetime: 30, // minutes
@@ -61,11 +134,72 @@ func LoadWithVersion(version string) flow.Options {
 		},
 		DbMigrationsPath:    "./migrations",
 		DbMigrationsAutorun: true,
+		DbRequestTimeout:    getEnvInt("DB_REQUEST_TIMEOUT", 30), // seconds
+		DbTxMaxRetries:      getEnvInt("DB_TX_MAX_RETRIES", 3),
+		DbTxRetryBackoff:    getEnvInt("DB_TX_RETRY_BACKOFF", 50), // milliseconds
+
+		DbReplicaBalancer:       getEnv("DB_REPLICA_BALANCER", "round-robin"), // round-robin or least-connections
+		DbReplicaHealthInterval: getEnvInt("DB_REPLICA_HEALTH_INTERVAL", 10),  // seconds
+
+		JwtSigningMethod:   jwtSigningMethod,
+		JwtSecret:          getSecretEnv("JWT_SECRET", production && jwtSigningMethod == "HS256", 32),
+		JwtPrivateKeyPath:  getEnv("JWT_PRIVATE_KEY_PATH", ""),
+		JwtPublicKeyPath:   getEnv("JWT_PUBLIC_KEY_PATH", ""),
+		JwtIssuer:          getEnv("JWT_ISSUER", "template-api"),
+		JwtAccessTokenTTL:  getEnvInt("JWT_ACCESS_TOKEN_TTL", 15),   // minutes
+		JwtRefreshTokenTTL: getEnvInt("JWT_REFRESH_TOKEN_TTL", 720), // hours
+
+		AppURL: getEnv("APP_URL", "http://localhost:5000"),
+
+		MailDriver:   getEnv("MAIL_DRIVER", "file"),
+		MailFrom:     getEnv("MAIL_FROM", "no-reply@localhost"),
+		MailFileDir:  getEnv("MAIL_FILE_DIR", "./tmp/mails"),
+		SmtpHost:     getEnv("SMTP_HOST", "localhost"),
+		SmtpPort:     getEnvInt("SMTP_PORT", 25),
+		SmtpUsername: getEnv("SMTP_USERNAME", ""),
+		SmtpPassword: getEnv("SMTP_PASSWORD", ""),
+
+		EmailVerificationTokenTTL:       getEnvInt("EMAIL_VERIFICATION_TOKEN_TTL", 24),       // hours
+		EmailVerificationResendInterval: getEnvInt("EMAIL_VERIFICATION_RESEND_INTERVAL", 60), // seconds
+
+		PasswordResetTokenTTL: getEnvInt("PASSWORD_RESET_TOKEN_TTL", 60), // minutes
+
+		SmsDriver:          getEnv("SMS_DRIVER", "log"),
+		PhoneDefaultRegion: getEnv("PHONE_DEFAULT_REGION", "US"),
+
+		OtpHashKey:        getSecretEnv("OTP_HASH_KEY", production, 32),
+		OtpLength:         getEnvInt("OTP_LENGTH", 6),
+		OtpTTL:            getEnvInt("OTP_TTL", 10), // minutes
+		OtpMaxAttempts:    getEnvInt("OTP_MAX_ATTEMPTS", 5),
+		OtpResendInterval: getEnvInt("OTP_RESEND_INTERVAL", 60), // seconds
+
+		TwoFactorEncryptionKey: getSecretEnv("TWO_FACTOR_ENCRYPTION_KEY", production, 32),
+		TwoFactorIssuer:        getEnv("TWO_FACTOR_ISSUER", "template-api"),
+		TwoFactorRecoveryCodes: getEnvInt("TWO_FACTOR_RECOVERY_CODES", 10),
+		TwoFactorChallengeTTL:  getEnvInt("TWO_FACTOR_CHALLENGE_TTL", 5), // minutes
+
+		InvitationTTL:          getEnvInt("INVITATION_TTL", 168), // hours
+		InvitationTreeMaxDepth: getEnvInt("INVITATION_TREE_MAX_DEPTH", 5),
+
+		AdminEmail: getEnv("ADMIN_EMAIL", ""),
+
+		UserPurgeRetention: getEnvInt("USER_PURGE_RETENTION", 30), // days
+		UserPurgeInterval:  getEnvInt("USER_PURGE_INTERVAL", 24),  // hours
+
+		PagingCursorSecret: getSecretEnv("PAGING_CURSOR_SECRET", production, 32),
+
+		SearchDriver: getEnv("SEARCH_DRIVER", "mysql"),
+
+		ErrorFormat:        getEnv("ERROR_FORMAT", "json"), // json or problem
+		ProblemTypeBaseURI: getEnv("PROBLEM_TYPE_BASE_URI", "/problems"),
+
+		LocalesPath:     getEnv("LOCALES_PATH", "./locales"),
+		DefaultLanguage: getEnv("DEFAULT_LANGUAGE", "en"),
 	}
 
 	// get application options
 	opts := flow.NewOptions()
-	opts.Env = getEnv("ENV", "development")
+	opts.Env = env
 	opts.Addr = getEnv("ADDR", opts.Addr)
 	opts.LogLevel = getEnv("LOG_LEVEL", "debug")
 	opts.Version = version
@@ -89,6 +223,18 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvList returns comma separated values for given key from environment
+// if key is not present in environment it returns nil
+func getEnvList(key string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
 // getEnvInt returns integer value for given key from environment
 // if key is not present in environment it returns defaultValue
 // if key cannot be parsed to integer function will panic
@@ -125,6 +271,30 @@ func getEnvFloat(key string, defaultValue float32) float32 {
 	return float32(valFloat)
 }
 
+// getSecretEnv returns secret for given key from environment
+// if key is not present in environment it returns developmentSecret, unless secret is required.
+// required secret has to differ from developmentSecret, and every present secret
+// has to be at least minLength characters long, otherwise function will exit
+func getSecretEnv(key string, required bool, minLength int) string {
+	v := os.Getenv(key)
+	if len(v) == 0 {
+		if required {
+			log.Fatalf(" variable `%s` is required in production", key)
+		}
+		return developmentSecret
+	}
+
+	if required && v == developmentSecret {
+		log.Fatalf(" variable `%s` cannot use development secret in production", key)
+	}
+
+	if len(v) < minLength {
+		log.Fatalf(" variable `%s` has to be at least %d characters long", key, minLength)
+	}
+
+	return v
+}
+
 // mustGetEnv returns value for given key from environment
 // if key is not present in environment function will panic
 func mustGetEnv(key string) string {
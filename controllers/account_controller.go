@@ -0,0 +1,427 @@
+package controllers
+
+import (
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/business"
+	"github.com/go-flow/template-api/domain/requests"
+	"github.com/go-flow/template-api/domain/views"
+	"github.com/go-flow/template-api/middlewares"
+)
+
+// AccountController -
+type AccountController struct {
+	BaseController
+
+	// AccountBusiness implementation injected by dependency injection
+	AccountBusiness business.AccountBusiness
+}
+
+// Init initialize controller
+func (ctrl *AccountController) Init(app *flow.App) {
+	ctrl.BaseController.Init(app)
+}
+
+// Routes returns controller routing definition
+func (ctrl *AccountController) Routes() *flow.Router {
+	r := flow.NewRouter()
+	r.POST("/register", ctrl.RegisterPostAction)
+	r.POST("/login", ctrl.LoginPostAction)
+	r.POST("/login/two-factor", ctrl.LoginTwoFactorPostAction)
+	r.POST("/token/refresh", ctrl.TokenRefreshPostAction)
+	r.POST("/logout", middlewares.Authenticate(ctrl.AccountBusiness), ctrl.LogoutPostAction)
+	r.POST("/email/verify", ctrl.EmailVerifyPostAction)
+	r.POST("/email/verify/resend", middlewares.Authenticate(ctrl.AccountBusiness), ctrl.EmailVerifyResendPostAction)
+	r.POST("/password/forgot", ctrl.PasswordForgotPostAction)
+	r.POST("/password/reset", ctrl.PasswordResetPostAction)
+	r.POST("/phone/verify/request", middlewares.Authenticate(ctrl.AccountBusiness), ctrl.PhoneVerifyRequestPostAction)
+	r.POST("/phone/verify/confirm", middlewares.Authenticate(ctrl.AccountBusiness), ctrl.PhoneVerifyConfirmPostAction)
+	r.POST("/two-factor/enroll", middlewares.Authenticate(ctrl.AccountBusiness), ctrl.TwoFactorEnrollPostAction)
+	r.POST("/two-factor/confirm", middlewares.Authenticate(ctrl.AccountBusiness), ctrl.TwoFactorConfirmPostAction)
+	r.POST("/two-factor/disable", middlewares.Authenticate(ctrl.AccountBusiness), ctrl.TwoFactorDisablePostAction)
+	return r
+}
+
+// RegisterPostAction registers new user account
+// @Summary This action creates new user account with email and password
+// @Accept json
+// @Produce json
+// @Tags account
+// @Param account body requests.AccountRegister true "Account"
+// @Success 201 {object} views.User
+// @Failure 400 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Router /account/register [post]
+func (ctrl *AccountController) RegisterPostAction(ctx *flow.Context) {
+	req := new(requests.AccountRegister)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	user, err := ctrl.AccountBusiness.Register(ctx.Request.Context(), req.FirstName, req.LastName, req.Email, req.Password)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderCreated(ctx, views.NewUser(user, views.Self(user.ID)))
+}
+
+// LoginPostAction checks user account credentials
+// @Summary This action authenticates user with email and password and issues access and refresh tokens, or two-factor challenge when enabled
+// @Accept json
+// @Produce json
+// @Tags account
+// @Param credentials body requests.AccountLogin true "Credentials"
+// @Success 200 {object} views.AuthTokens
+// @Success 202 {object} models.TwoFactorChallenge
+// @Failure 400 {object} models.ResponseError
+// @Failure 401 {object} models.ResponseError
+// @Failure 403 {object} models.ResponseError
+// @Router /account/login [post]
+func (ctrl *AccountController) LoginPostAction(ctx *flow.Context) {
+	req := new(requests.AccountLogin)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	tokens, challenge, err := ctrl.AccountBusiness.Login(ctx.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	if challenge != nil {
+		ctrl.RenderAccepted(ctx, challenge)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, views.NewAuthTokens(tokens))
+}
+
+// LoginTwoFactorPostAction completes two-factor login
+// @Summary This action exchanges login challenge and authentication or recovery code for access and refresh tokens
+// @Accept json
+// @Produce json
+// @Tags account
+// @Param challenge body requests.AccountLoginTwoFactor true "Two-factor challenge"
+// @Success 200 {object} views.AuthTokens
+// @Failure 400 {object} models.ResponseError
+// @Failure 401 {object} models.ResponseError
+// @Failure 403 {object} models.ResponseError
+// @Router /account/login/two-factor [post]
+func (ctrl *AccountController) LoginTwoFactorPostAction(ctx *flow.Context) {
+	req := new(requests.AccountLoginTwoFactor)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	tokens, err := ctrl.AccountBusiness.LoginTwoFactor(ctx.Request.Context(), req.ChallengeToken, req.Code)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, views.NewAuthTokens(tokens))
+}
+
+// TokenRefreshPostAction exchanges refresh token for new token pair
+// @Summary This action rotates refresh token and issues new access and refresh tokens
+// @Accept json
+// @Produce json
+// @Tags account
+// @Param token body requests.AccountTokenRefresh true "Refresh token"
+// @Success 200 {object} views.AuthTokens
+// @Failure 400 {object} models.ResponseError
+// @Failure 401 {object} models.ResponseError
+// @Failure 403 {object} models.ResponseError
+// @Router /account/token/refresh [post]
+func (ctrl *AccountController) TokenRefreshPostAction(ctx *flow.Context) {
+	req := new(requests.AccountTokenRefresh)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	tokens, err := ctrl.AccountBusiness.Refresh(ctx.Request.Context(), req.RefreshToken)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, views.NewAuthTokens(tokens))
+}
+
+// LogoutPostAction revokes refresh tokens of authenticated user
+// @Summary This action revokes given refresh token, or all user refresh tokens
+// @Accept json
+// @Produce json
+// @Tags account
+// @Security BearerAuth
+// @Param logout body requests.AccountLogout true "Logout"
+// @Success 200 {object} flow.VM
+// @Failure 400 {object} models.ResponseError
+// @Failure 401 {object} models.ResponseError
+// @Router /account/logout [post]
+func (ctrl *AccountController) LogoutPostAction(ctx *flow.Context) {
+	req := new(requests.AccountLogout)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	user, _ := middlewares.CurrentUser(ctx)
+
+	err := ctrl.AccountBusiness.Logout(ctx.Request.Context(), user.ID, req.RefreshToken, req.All)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, flow.VM{
+		"logged_out": true,
+	})
+}
+
+// EmailVerifyPostAction verifies user email address
+// @Summary This action marks user email as verified using token sent by email
+// @Accept json
+// @Produce json
+// @Tags account
+// @Param token body requests.AccountEmailVerify true "Verification token"
+// @Success 200 {object} views.User
+// @Failure 400 {object} models.ResponseError
+// @Router /account/email/verify [post]
+func (ctrl *AccountController) EmailVerifyPostAction(ctx *flow.Context) {
+	req := new(requests.AccountEmailVerify)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	user, err := ctrl.AccountBusiness.VerifyEmail(ctx.Request.Context(), req.Token)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, views.NewUser(user, views.Self(user.ID)))
+}
+
+// EmailVerifyResendPostAction sends new email verification link
+// @Summary This action sends new email verification link to authenticated user
+// @Produce json
+// @Tags account
+// @Security BearerAuth
+// @Success 202 {object} flow.VM
+// @Failure 401 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Failure 429 {object} models.ResponseError
+// @Router /account/email/verify/resend [post]
+func (ctrl *AccountController) EmailVerifyResendPostAction(ctx *flow.Context) {
+	user, _ := middlewares.CurrentUser(ctx)
+
+	err := ctrl.AccountBusiness.SendEmailVerification(ctx.Request.Context(), user.ID)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderAccepted(ctx, flow.VM{
+		"sent": true,
+	})
+}
+
+// PasswordForgotPostAction sends password reset link
+// @Summary This action sends password reset link to given email, response does not reveal whether account exists
+// @Accept json
+// @Produce json
+// @Tags account
+// @Param email body requests.AccountPasswordForgot true "Email"
+// @Success 202 {object} flow.VM
+// @Failure 400 {object} models.ResponseError
+// @Router /account/password/forgot [post]
+func (ctrl *AccountController) PasswordForgotPostAction(ctx *flow.Context) {
+	req := new(requests.AccountPasswordForgot)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	if err := ctrl.AccountBusiness.ForgotPassword(ctx.Request.Context(), req.Email); err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderAccepted(ctx, flow.VM{
+		"sent": true,
+	})
+}
+
+// PasswordResetPostAction sets new account password
+// @Summary This action sets new password using token sent by email and signs user out of all sessions
+// @Accept json
+// @Produce json
+// @Tags account
+// @Param reset body requests.AccountPasswordReset true "Password reset"
+// @Success 200 {object} flow.VM
+// @Failure 400 {object} models.ResponseError
+// @Router /account/password/reset [post]
+func (ctrl *AccountController) PasswordResetPostAction(ctx *flow.Context) {
+	req := new(requests.AccountPasswordReset)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	err := ctrl.AccountBusiness.ResetPassword(ctx.Request.Context(), req.Token, req.Password)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, flow.VM{
+		"reset": true,
+	})
+}
+
+// PhoneVerifyRequestPostAction sends phone verification code
+// @Summary This action sends one time verification code to phone number of authenticated user
+// @Produce json
+// @Tags account
+// @Security BearerAuth
+// @Success 202 {object} flow.VM
+// @Failure 400 {object} models.ResponseError
+// @Failure 401 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Failure 429 {object} models.ResponseError
+// @Router /account/phone/verify/request [post]
+func (ctrl *AccountController) PhoneVerifyRequestPostAction(ctx *flow.Context) {
+	user, _ := middlewares.CurrentUser(ctx)
+
+	err := ctrl.AccountBusiness.SendPhoneVerification(ctx.Request.Context(), user.ID)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderAccepted(ctx, flow.VM{
+		"sent": true,
+	})
+}
+
+// PhoneVerifyConfirmPostAction verifies user phone number
+// @Summary This action marks phone number of authenticated user as verified using code sent by SMS
+// @Accept json
+// @Produce json
+// @Tags account
+// @Security BearerAuth
+// @Param code body requests.AccountPhoneVerify true "Verification code"
+// @Success 200 {object} views.User
+// @Failure 400 {object} models.ResponseError
+// @Failure 401 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Failure 429 {object} models.ResponseError
+// @Router /account/phone/verify/confirm [post]
+func (ctrl *AccountController) PhoneVerifyConfirmPostAction(ctx *flow.Context) {
+	req := new(requests.AccountPhoneVerify)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	current, _ := middlewares.CurrentUser(ctx)
+
+	user, err := ctrl.AccountBusiness.VerifyPhone(ctx.Request.Context(), current.ID, req.Code)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, views.NewUser(user, views.Self(user.ID)))
+}
+
+// TwoFactorEnrollPostAction starts two-factor enrollment
+// @Summary This action generates TOTP secret for authenticated user, it has to be confirmed before it is used
+// @Produce json
+// @Tags account
+// @Security BearerAuth
+// @Success 200 {object} models.TwoFactorEnrollment
+// @Failure 401 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Router /account/two-factor/enroll [post]
+func (ctrl *AccountController) TwoFactorEnrollPostAction(ctx *flow.Context) {
+	user, _ := middlewares.CurrentUser(ctx)
+
+	enrollment, err := ctrl.AccountBusiness.EnrollTwoFactor(ctx.Request.Context(), user.ID)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, enrollment)
+}
+
+// TwoFactorConfirmPostAction enables two-factor authentication
+// @Summary This action enables two-factor authentication using code from authenticator app and returns recovery codes
+// @Accept json
+// @Produce json
+// @Tags account
+// @Security BearerAuth
+// @Param code body requests.AccountTwoFactorCode true "Authentication code"
+// @Success 200 {object} models.TwoFactorRecoveryCodes
+// @Failure 400 {object} models.ResponseError
+// @Failure 401 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Router /account/two-factor/confirm [post]
+func (ctrl *AccountController) TwoFactorConfirmPostAction(ctx *flow.Context) {
+	req := new(requests.AccountTwoFactorCode)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	user, _ := middlewares.CurrentUser(ctx)
+
+	codes, err := ctrl.AccountBusiness.ConfirmTwoFactor(ctx.Request.Context(), user.ID, req.Code)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, codes)
+}
+
+// TwoFactorDisablePostAction disables two-factor authentication
+// @Summary This action disables two-factor authentication using code from authenticator app or recovery code
+// @Accept json
+// @Produce json
+// @Tags account
+// @Security BearerAuth
+// @Param code body requests.AccountTwoFactorCode true "Authentication or recovery code"
+// @Success 200 {object} flow.VM
+// @Failure 400 {object} models.ResponseError
+// @Failure 401 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Router /account/two-factor/disable [post]
+func (ctrl *AccountController) TwoFactorDisablePostAction(ctx *flow.Context) {
+	req := new(requests.AccountTwoFactorCode)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	user, _ := middlewares.CurrentUser(ctx)
+
+	err := ctrl.AccountBusiness.DisableTwoFactor(ctx.Request.Context(), user.ID, req.Code)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, flow.VM{
+		"disabled": true,
+	})
+}
@@ -3,20 +3,32 @@ package controllers
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/go-flow/template-api/config"
+	apperrors "github.com/go-flow/template-api/domain/errors"
 	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/pkg/paging"
+	"github.com/go-flow/template-api/services"
 
 	"github.com/go-flow/flow"
+	"github.com/go-flow/flow/render"
 	"github.com/go-playground/validator/v10"
+	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 )
 
+// problemContentType is media type of RFC 7807 problem details
+const problemContentType = "application/problem+json"
+
 // BaseController is base for all application controllers
 // and contains common functionalities and helpers for other controllers
 type BaseController struct {
 	AppConfig config.AppConfig
+
+	// LocaleService implementation injected by dependency injection
+	LocaleService services.LocaleService
 }
 
 // Init initialize BaseController
@@ -31,6 +43,33 @@ func (ctrl *BaseController) Router() *flow.Router {
 	return r
 }
 
+// ParamID returns route parameter with given key parsed as unsigned integer identifier
+func (ctrl *BaseController) ParamID(ctx *flow.Context, key string) (uint64, error) {
+	id, err := strconv.ParseUint(ctx.Param(key), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid `%s` parameter", key)
+	}
+	return id, nil
+}
+
+// Language returns locale error messages are rendered in
+//
+// `app-language` header takes precedence over `Accept-Language`,
+// empty string is returned when messages are not localized
+func (ctrl *BaseController) Language(ctx *flow.Context) string {
+	if ctrl.LocaleService == nil {
+		return ""
+	}
+	return ctrl.LocaleService.Negotiate(ctx.Header("app-language"), ctx.Header("Accept-Language"))
+}
+
+// Fields returns sparse fieldset requested with `fields` query parameter
+//
+// *paging.FieldsError is returned when requested field is not in whitelist
+func (ctrl *BaseController) Fields(ctx *flow.Context, whitelist paging.FieldWhitelist) (paging.Fields, error) {
+	return paging.ParseFields(ctx.Query("fields"), whitelist)
+}
+
 // Render renders JSON response for given parameters
 func (ctrl *BaseController) Render(success bool, status int, ctx *flow.Context, data interface{}, err interface{}) {
 	ctx.Status(status)
@@ -47,6 +86,27 @@ func (ctrl *BaseController) RenderSuccess(ctx *flow.Context, data interface{}) {
 	ctrl.Render(true, http.StatusOK, ctx, data, nil)
 }
 
+// RenderSuccessFields renders JSON response with http status 200 narrowed to sparse fieldset
+//
+// results of paginated model are narrowed, while paginator is rendered as is
+func (ctrl *BaseController) RenderSuccessFields(ctx *flow.Context, data interface{}, fields paging.Fields) {
+	var err error
+
+	if page, ok := data.(models.PaginatedModel); ok {
+		page.Results, err = fields.Project(page.Results)
+		data = page
+	} else {
+		data, err = fields.Project(data)
+	}
+
+	if err != nil {
+		ctrl.RenderInternalServerError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, data)
+}
+
 // RenderError renders Error message to JSON response
 func (ctrl *BaseController) RenderError(ctx *flow.Context, code int, err error) {
 	trace := ""
@@ -61,23 +121,135 @@ func (ctrl *BaseController) RenderError(ctx *flow.Context, code int, err error)
 		Stack:   trace,
 	}
 
+	lang := ctrl.Language(ctx)
+	if lang != "" {
+		ctx.SetHeader("Content-Language", lang)
+	}
+
+	// check if error carries machine readable code
+	if derr, ok := apperrors.As(err); ok {
+		vm.Code = derr.Code
+	}
+
+	// replace message of coded error with catalog translation
+	if vm.Code != 0 && lang != "" {
+		if msg, ok := ctrl.LocaleService.ErrorMessage(lang, vm.Code); ok {
+			vm.Message = msg
+		}
+	}
+
 	// check if httpError is caused by validation
 	if verrs, ok := errors.Cause(err).(validator.ValidationErrors); ok {
 		m := map[string]string{}
+		msgs := map[string]string{}
 
 		for _, verr := range verrs {
 			m[verr.Field()] = fmt.Sprintf("%s_%s", strings.ToLower(verr.Field()), verr.Tag())
+			if lang != "" {
+				msgs[verr.Field()] = ctrl.LocaleService.ValidationMessage(lang, m[verr.Field()], verr)
+			}
 		}
 
 		vm.Validation = m
+		if lang != "" {
+			vm.Messages = msgs
+		}
+	}
+
+	if ctrl.acceptsProblem(ctx) {
+		ctrl.RenderProblem(ctx, code, vm)
+		return
 	}
 
 	ctrl.Render(false, code, ctx, nil, vm)
 }
 
+// RenderProblem renders error view model as RFC 7807 problem details
+//
+// instance holds request ID, and validation errors are rendered in `errors` extension
+func (ctrl *BaseController) RenderProblem(ctx *flow.Context, status int, vm models.ResponseError) {
+	title := http.StatusText(status)
+
+	// validator messages list every field, those are rendered in `errors` instead
+	slug, detail := title, vm.Message
+	if vm.Validation != nil {
+		slug, detail = "Validation Error", "request has invalid fields"
+	}
+
+	problem := models.Problem{
+		Type:     ctrl.problemType(slug),
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: ctx.RequestID(),
+		Code:     vm.Code,
+		Errors:   vm.Validation,
+		Messages: vm.Messages,
+	}
+
+	ctx.SetContentType([]string{problemContentType})
+	ctx.Render(status, render.JSON{Data: problem})
+}
+
+// acceptsProblem reports whether errors are rendered as problem details,
+// either by configuration or because client accepts `application/problem+json`
+func (ctrl *BaseController) acceptsProblem(ctx *flow.Context) bool {
+	if ctrl.AppConfig.ErrorFormat == "problem" {
+		return true
+	}
+	return strings.Contains(ctx.Header("Accept"), problemContentType)
+}
+
+// problemType returns problem type URI for name, e.g. `/problems/not-found` for `Not Found`
+//
+// `about:blank` is returned when base URI is not configured
+func (ctrl *BaseController) problemType(name string) string {
+	if ctrl.AppConfig.ProblemTypeBaseURI == "" {
+		return "about:blank"
+	}
+
+	slug := strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	}), "-")
+
+	return strings.TrimSuffix(ctrl.AppConfig.ProblemTypeBaseURI, "/") + "/" + slug
+}
+
+// RenderDomainError renders error with http status matching its kind
+//
+// gorm.ErrRecordNotFound is rendered as not found error,
+// and errors which are not domain errors as internal server error
+func (ctrl *BaseController) RenderDomainError(ctx *flow.Context, err error) {
+	if gorm.IsRecordNotFoundError(errors.Cause(err)) {
+		err = apperrors.NotFound(apperrors.CodeRecordNotFound, "record not found").Wrap(err)
+	}
+
+	switch apperrors.KindOf(err) {
+	case apperrors.KindNotFound:
+		ctrl.RenderNotFoundError(ctx, err)
+	case apperrors.KindConflict:
+		ctrl.RenderConflictError(ctx, err)
+	case apperrors.KindValidation:
+		ctrl.RenderBadRequestError(ctx, err)
+	case apperrors.KindUnauthorized:
+		ctrl.RenderUnauthorizedError(ctx, err)
+	case apperrors.KindForbidden:
+		ctrl.RenderForbiddenError(ctx, err)
+	case apperrors.KindRateLimited:
+		ctrl.RenderTooManyRequestsError(ctx, err)
+	default:
+		ctrl.RenderInternalServerError(ctx, err)
+	}
+}
+
 // GenericErrorAction -
 func (ctrl *BaseController) GenericErrorAction(ctx *flow.Context) {
-	err := ctx.Errors.Last()
+	// unwrap error served by middlewares so its type is preserved
+	err := errors.New(http.StatusText(ctx.Response.Status()))
+	if last := ctx.Errors.Last(); last != nil {
+		err = last.Err
+	}
+
 	switch ctx.Response.Status() {
 	case http.StatusForbidden:
 		ctrl.RenderForbiddenError(ctx, err)
@@ -134,6 +306,16 @@ func (ctrl *BaseController) RenderNotAllowed(ctx *flow.Context, err error) {
 	ctrl.RenderError(ctx, http.StatusMethodNotAllowed, err)
 }
 
+// RenderConflictError renders JSON response with http status 409
+func (ctrl *BaseController) RenderConflictError(ctx *flow.Context, err error) {
+	ctrl.RenderError(ctx, http.StatusConflict, err)
+}
+
+// RenderTooManyRequestsError renders JSON response with http status 429
+func (ctrl *BaseController) RenderTooManyRequestsError(ctx *flow.Context, err error) {
+	ctrl.RenderError(ctx, http.StatusTooManyRequests, err)
+}
+
 // RenderInternalServerError renders JSON response with http status 500
 func (ctrl *BaseController) RenderInternalServerError(ctx *flow.Context, err error) {
 	ctrl.RenderError(ctx, http.StatusInternalServerError, err)
@@ -1,7 +1,11 @@
 package controllers
 
 import (
+	"time"
+
 	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/config"
+	"github.com/go-flow/template-api/middlewares"
 	"github.com/go-flow/template-api/pkg/cors"
 	"github.com/go-flow/template-api/pkg/swagger"
 
@@ -11,12 +15,20 @@ import (
 // Init initializes http handlers
 func Init(app *flow.App) {
 
+	cfg := app.AppConfig.(config.AppConfig)
+
 	// use CORS middleware
 	app.Use(cors.Default())
 
+	// bound database work of every request
+	app.Use(middlewares.RequestContext(time.Duration(cfg.DbRequestTimeout) * time.Second))
+
 	// Register Controller to Flow application
 	app.RegisterController(new(IndexController))
 	app.RegisterController(new(UsersController))
+	app.RegisterController(new(AccountController))
+	app.RegisterController(new(InvitationsController))
+	app.RegisterController(new(TosController))
 
 	if app.Env == "development" {
 		//init Swagger
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/business"
+	"github.com/go-flow/template-api/domain/requests"
+	"github.com/go-flow/template-api/domain/views"
+	"github.com/go-flow/template-api/middlewares"
+	"github.com/go-flow/template-api/services"
+	"github.com/jinzhu/gorm"
+)
+
+// InvitationsController -
+type InvitationsController struct {
+	BaseController
+
+	// InvitationBusiness implementation injected by dependency injection
+	InvitationBusiness business.InvitationBusiness
+
+	// AccountBusiness implementation injected by dependency injection
+	AccountBusiness business.AccountBusiness
+
+	// RoleBusiness implementation injected by dependency injection
+	RoleBusiness business.RoleBusiness
+
+	// TosBusiness implementation injected by dependency injection
+	TosBusiness business.TosBusiness
+}
+
+// Init initialize controller
+func (ctrl *InvitationsController) Init(app *flow.App) {
+	ctrl.BaseController.Init(app)
+}
+
+// Routes returns controller routing definition
+func (ctrl *InvitationsController) Routes() *flow.Router {
+	authenticate := middlewares.Authenticate(ctrl.AccountBusiness)
+	tos := middlewares.RequireTosAccepted(ctrl.TosBusiness)
+
+	r := flow.NewRouter()
+	r.GET("/", authenticate, tos, ctrl.IndexGetAction)
+	r.POST("/", authenticate, tos, middlewares.RequirePermission(ctrl.RoleBusiness, "invitations:create"), ctrl.CreatePostAction)
+	r.DELETE("/:id", authenticate, tos, ctrl.DestroyDeleteAction)
+	r.POST("/accept", ctrl.AcceptPostAction)
+	return r
+}
+
+// IndexGetAction returns invitations sent by authenticated user
+// @Summary This action returns list of invitations sent by authenticated user
+// @Produce json
+// @Tags invitations
+// @Security BearerAuth
+// @Success 200 {array} models.Invitation
+// @Failure 401 {object} models.ResponseError
+// @Router /invitations/ [get]
+func (ctrl *InvitationsController) IndexGetAction(ctx *flow.Context) {
+	user, _ := middlewares.CurrentUser(ctx)
+
+	invitations, err := ctrl.InvitationBusiness.GetAllByInviter(ctx.Request.Context(), user.ID)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, invitations)
+}
+
+// CreatePostAction invites new user
+// @Summary This action creates invitation and sends it to given email
+// @Accept json
+// @Produce json
+// @Tags invitations
+// @Security BearerAuth
+// @Param invitation body requests.InvitationCreate true "Invitation"
+// @Success 201 {object} models.Invitation
+// @Failure 400 {object} models.ResponseError
+// @Failure 403 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Router /invitations/ [post]
+func (ctrl *InvitationsController) CreatePostAction(ctx *flow.Context) {
+	req := new(requests.InvitationCreate)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	user, _ := middlewares.CurrentUser(ctx)
+
+	invitation, err := ctrl.InvitationBusiness.Create(ctx.Request.Context(), user.ID, req.Email)
+	switch err {
+	case nil:
+		ctrl.RenderCreated(ctx, invitation)
+	case business.ErrEmailTaken, business.ErrInvitationPending:
+		ctrl.RenderConflictError(ctx, err)
+	default:
+		ctrl.RenderDomainError(ctx, err)
+	}
+}
+
+// DestroyDeleteAction revokes invitation
+// @Summary This action revokes pending invitation sent by authenticated user
+// @Produce json
+// @Tags invitations
+// @Security BearerAuth
+// @Param id path int true "Invitation ID"
+// @Success 200 {object} models.Invitation
+// @Failure 400 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Router /invitations/{id} [delete]
+func (ctrl *InvitationsController) DestroyDeleteAction(ctx *flow.Context) {
+	id, err := ctrl.ParamID(ctx, "id")
+	if err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	user, _ := middlewares.CurrentUser(ctx)
+
+	invitation, err := ctrl.InvitationBusiness.Revoke(ctx.Request.Context(), user.ID, id)
+	if gorm.IsRecordNotFoundError(err) {
+		ctrl.RenderNotFoundError(ctx, err)
+		return
+	}
+	if err == services.ErrInvitationNotPending {
+		ctrl.RenderConflictError(ctx, err)
+		return
+	}
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, invitation)
+}
+
+// AcceptPostAction accepts invitation
+// @Summary This action creates user account for invitation token sent by email
+// @Accept json
+// @Produce json
+// @Tags invitations
+// @Param invitation body requests.InvitationAccept true "Invitation"
+// @Success 201 {object} views.User
+// @Failure 400 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Router /invitations/accept [post]
+func (ctrl *InvitationsController) AcceptPostAction(ctx *flow.Context) {
+	req := new(requests.InvitationAccept)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	user, err := ctrl.InvitationBusiness.Accept(ctx.Request.Context(), req.Token, req.FirstName, req.LastName, req.Password)
+	switch err {
+	case nil:
+		ctrl.RenderCreated(ctx, views.NewUser(user, views.Self(user.ID)))
+	case services.ErrInvalidInvitation:
+		ctrl.RenderBadRequestError(ctx, err)
+	case business.ErrEmailTaken, services.ErrInvitationNotPending:
+		ctrl.RenderConflictError(ctx, err)
+	default:
+		ctrl.RenderDomainError(ctx, err)
+	}
+}
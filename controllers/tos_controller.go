@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/business"
+	"github.com/go-flow/template-api/domain/requests"
+	"github.com/go-flow/template-api/middlewares"
+	"github.com/go-flow/template-api/services"
+)
+
+// TosController -
+type TosController struct {
+	BaseController
+
+	// TosBusiness implementation injected by dependency injection
+	TosBusiness business.TosBusiness
+
+	// AccountBusiness implementation injected by dependency injection
+	AccountBusiness business.AccountBusiness
+
+	// RoleBusiness implementation injected by dependency injection
+	RoleBusiness business.RoleBusiness
+}
+
+// Init initialize controller
+func (ctrl *TosController) Init(app *flow.App) {
+	ctrl.BaseController.Init(app)
+}
+
+// Routes returns controller routing definition
+func (ctrl *TosController) Routes() *flow.Router {
+	authenticate := middlewares.Authenticate(ctrl.AccountBusiness)
+
+	r := flow.NewRouter()
+	r.GET("/current", ctrl.CurrentGetAction)
+	r.POST("/", authenticate, middlewares.RequirePermission(ctrl.RoleBusiness, "tos:publish"), ctrl.CreatePostAction)
+	r.POST("/accept", authenticate, ctrl.AcceptPostAction)
+	r.GET("/acceptances", authenticate, ctrl.AcceptancesGetAction)
+	return r
+}
+
+// CurrentGetAction returns current terms of service version
+// @Summary This action returns most recently published terms of service version
+// @Produce json
+// @Tags tos
+// @Success 200 {object} models.TosVersion
+// @Failure 404 {object} models.ResponseError
+// @Router /tos/current [get]
+func (ctrl *TosController) CurrentGetAction(ctx *flow.Context) {
+	version, err := ctrl.TosBusiness.GetCurrent(ctx.Request.Context())
+	if err == services.ErrNoTosVersion {
+		ctrl.RenderNotFoundError(ctx, err)
+		return
+	}
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, version)
+}
+
+// CreatePostAction publishes terms of service version
+// @Summary This action publishes new terms of service version, users have to accept it once it becomes current
+// @Accept json
+// @Produce json
+// @Tags tos
+// @Security BearerAuth
+// @Param version body requests.TosPublish true "Terms of service version"
+// @Success 201 {object} models.TosVersion
+// @Failure 400 {object} models.ResponseError
+// @Failure 403 {object} models.ResponseError
+// @Router /tos/ [post]
+func (ctrl *TosController) CreatePostAction(ctx *flow.Context) {
+	req := new(requests.TosPublish)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	version, err := ctrl.TosBusiness.Publish(ctx.Request.Context(), req.Version, req.Summary, req.URL, req.PublishedAt)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderCreated(ctx, version)
+}
+
+// AcceptPostAction accepts current terms of service version
+// @Summary This action records authenticated user accepting current terms of service version
+// @Accept json
+// @Produce json
+// @Tags tos
+// @Security BearerAuth
+// @Param acceptance body requests.TosAccept true "Accepted version"
+// @Success 200 {object} models.UserTosAcceptance
+// @Failure 400 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Router /tos/accept [post]
+func (ctrl *TosController) AcceptPostAction(ctx *flow.Context) {
+	req := new(requests.TosAccept)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	user, _ := middlewares.CurrentUser(ctx)
+
+	acceptance, err := ctrl.TosBusiness.Accept(ctx.Request.Context(), user.ID, req.TosVersionID, ctx.ClientIP(), ctx.Request.UserAgent())
+	switch err {
+	case nil:
+		ctrl.RenderSuccess(ctx, acceptance)
+	case services.ErrNoTosVersion:
+		ctrl.RenderNotFoundError(ctx, err)
+	case business.ErrTosVersionOutdated:
+		ctrl.RenderConflictError(ctx, err)
+	default:
+		ctrl.RenderDomainError(ctx, err)
+	}
+}
+
+// AcceptancesGetAction returns terms of service acceptance history
+// @Summary This action returns terms of service versions accepted by authenticated user
+// @Produce json
+// @Tags tos
+// @Security BearerAuth
+// @Success 200 {array} models.UserTosAcceptance
+// @Failure 401 {object} models.ResponseError
+// @Router /tos/acceptances [get]
+func (ctrl *TosController) AcceptancesGetAction(ctx *flow.Context) {
+	user, _ := middlewares.CurrentUser(ctx)
+
+	acceptances, err := ctrl.TosBusiness.GetAcceptances(ctx.Request.Context(), user.ID)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, acceptances)
+}
@@ -1,10 +1,17 @@
 package controllers
 
 import (
+	"net/http"
+	"strconv"
+
 	"github.com/go-flow/flow"
 	"github.com/go-flow/template-api/business"
 	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/domain/requests"
+	"github.com/go-flow/template-api/domain/views"
+	"github.com/go-flow/template-api/middlewares"
 	"github.com/go-flow/template-api/pkg/paging"
+	"github.com/pkg/errors"
 )
 
 // UsersController -
@@ -13,6 +20,15 @@ type UsersController struct {
 
 	// UserBusiness implementation injected by dependency injection
 	UserBusiness business.UserBusiness
+
+	// AccountBusiness implementation injected by dependency injection
+	AccountBusiness business.AccountBusiness
+
+	// RoleBusiness implementation injected by dependency injection
+	RoleBusiness business.RoleBusiness
+
+	// TosBusiness implementation injected by dependency injection
+	TosBusiness business.TosBusiness
 }
 
 // Init initialize controller
@@ -23,31 +39,649 @@ func (ctrl *UsersController) Init(app *flow.App) {
 // Routes returns controller routing definition
 func (ctrl *UsersController) Routes() *flow.Router {
 	r := flow.NewRouter()
-	r.GET("/", ctrl.IndexGetAction)
+	r.Use(middlewares.Authenticate(ctrl.AccountBusiness))
+	r.Use(middlewares.RequireTosAccepted(ctrl.TosBusiness))
+
+	r.GET("/", ctrl.can("users:read"), ctrl.IndexGetAction)
+	r.POST("/", ctrl.can("users:create"), ctrl.CreatePostAction)
+	// GET /search is dispatched by ShowGetAction, router does not allow
+	// static segment next to `:id` parameter on the same level
+	r.GET("/:id", ctrl.canShow(), ctrl.ShowGetAction)
+	r.PUT("/:id", ctrl.can("users:update"), ctrl.ReplacePutAction)
+	r.PATCH("/:id", ctrl.can("users:update"), ctrl.UpdatePatchAction)
+	r.DELETE("/:id", ctrl.can("users:delete"), ctrl.DestroyDeleteAction)
+	r.POST("/:id/restore", ctrl.can("users:delete"), ctrl.RestorePostAction)
+	r.DELETE("/:id/purge", ctrl.can("users:purge"), ctrl.PurgeDeleteAction)
+
+	r.GET("/:id/invitees", ctrl.can("users:read"), ctrl.InviteesGetAction)
+	r.POST("/:id/activate", ctrl.can("users:status"), ctrl.ActivatePostAction)
+	r.POST("/:id/deactivate", ctrl.can("users:status"), ctrl.DeactivatePostAction)
+	r.POST("/:id/suspend", ctrl.can("users:status"), ctrl.SuspendPostAction)
+	r.POST("/:id/reactivate", ctrl.can("users:status"), ctrl.ReactivatePostAction)
+	r.GET("/:id/status-transitions", ctrl.can("users:read"), ctrl.StatusTransitionsGetAction)
+	r.GET("/:id/roles", ctrl.can("users:read"), ctrl.RolesGetAction)
+	r.PUT("/:id/roles/:role", ctrl.can("roles:assign"), ctrl.RolesPutAction)
+	r.DELETE("/:id/roles/:role", ctrl.can("roles:assign"), ctrl.RolesDeleteAction)
 	return r
 }
 
+// can returns middleware which requires authenticated user to be granted given permission
+func (ctrl *UsersController) can(permission string) flow.HandlerFunc {
+	return middlewares.RequirePermission(ctrl.RoleBusiness, permission)
+}
+
+// canShow returns middleware which requires `users:search` permission for GET /search
+// and `users:read` permission for other GET /:id requests
+func (ctrl *UsersController) canShow() flow.HandlerFunc {
+	read := ctrl.can("users:read")
+	search := ctrl.can("users:search")
+
+	return func(ctx *flow.Context) {
+		if ctx.Param("id") == "search" {
+			search(ctx)
+			return
+		}
+		read(ctx)
+	}
+}
+
 // IndexGetAction returns list of users
 // @Summary This action returns list of users from data store
 // @Produce json
 // @Tags users
+// @Security BearerAuth
 // @Tags index
+// @Param sort query string false "Comma separated sort fields, prefix `-` for descending order, e.g. `-created_at,last_name`"
+// @Param filter[field][operator] query string false "Filter condition, operators are eq (default), ne, in, like, gte, lte and is-null, e.g. `filter[created_at][gte]=2024-01-01`, fields other than id, first_name, last_name, country, city and created_at require `users:private` permission"
+// @Param mode query string false "Pagination mode, `page` or `cursor`" Enums(page, cursor)
+// @Param cursor query string false "Cursor from `next_cursor` or `prev_cursor` of previous response, selects cursor mode"
+// @Param with_deleted query bool false "Include soft deleted users, requires `users:private` permission"
+// @Param only_deleted query bool false "Return only soft deleted users, requires `users:private` permission"
+// @Param fields query string false "Comma separated list of returned user fields, e.g. `id,email,first_name`"
 // @Success 200 {object} models.PaginatedModel
 // @Failure 400 {object} models.ResponseError
+// @Failure 403 {object} models.ResponseError
 // @Router /users/ [get]
 func (ctrl *UsersController) IndexGetAction(ctx *flow.Context) {
 	// get paging from query string
 	paginator := paging.NewPaginatorFromParams(ctx.Request.URL.Query())
 
-	users, err := ctrl.UserBusiness.GetAll(paginator)
+	fields, err := ctrl.Fields(ctx, models.UserFields)
+	if err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+	paginator.Fields = fields
 
+	viewer, err := ctrl.viewer(ctx)
 	if err != nil {
-		ctrl.RenderInternalServerError(ctx, err)
+		ctrl.RenderDomainError(ctx, err)
 		return
 	}
 
-	ctrl.RenderSuccess(ctx, models.PaginatedModel{
-		Results:   users,
+	// deleted users and private fields are available only to viewers who can see private fields
+	if (paginator.WithDeleted || paginator.OnlyDeleted) && !viewer.Admin {
+		ctrl.RenderForbiddenError(ctx, errors.New("permission `users:private` is required to list deleted users"))
+		return
+	}
+	paginator.Private = viewer.Admin
+
+	users, err := ctrl.UserBusiness.GetAll(ctx.Request.Context(), paginator)
+
+	if err != nil {
+		ctrl.renderBusinessError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccessFields(ctx, models.PaginatedModel{
+		Results:   views.NewUsers(users, viewer),
 		Paginator: paginator,
+	}, fields)
+}
+
+// ShowGetAction returns single user
+// @Summary This action returns user with given id from data store
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param fields query string false "Comma separated list of returned user fields, e.g. `id,email,first_name`"
+// @Success 200 {object} views.User
+// @Failure 400 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Router /users/{id} [get]
+func (ctrl *UsersController) ShowGetAction(ctx *flow.Context) {
+	if ctx.Param("id") == "search" {
+		ctrl.SearchGetAction(ctx)
+		return
+	}
+
+	id, err := ctrl.ParamID(ctx, "id")
+	if err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	fields, err := ctrl.Fields(ctx, models.UserFields)
+	if err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	user, err := ctrl.UserBusiness.GetByIDWithFields(ctx.Request.Context(), id, fields)
+	if err != nil {
+		ctrl.renderBusinessError(ctx, err)
+		return
+	}
+
+	viewer, err := ctrl.viewer(ctx)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccessFields(ctx, views.NewUser(user, viewer), fields)
+}
+
+// SearchGetAction returns users matching full-text query
+// @Summary This action returns users matching full-text query ordered by relevance, with highlighted matches
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param q query string true "Search query"
+// @Param limit query int false "Maximal number of results (default 20, max 100)"
+// @Success 200 {array} views.UserSearchResult
+// @Failure 400 {object} models.ResponseError
+// @Failure 403 {object} models.ResponseError
+// @Router /users/search [get]
+func (ctrl *UsersController) SearchGetAction(ctx *flow.Context) {
+	query := ctx.Request.URL.Query()
+
+	limit := 0
+	if raw := query.Get("limit"); raw != "" {
+		l, err := strconv.Atoi(raw)
+		if err != nil {
+			ctrl.RenderBadRequestError(ctx, errors.Wrap(err, "invalid `limit` parameter"))
+			return
+		}
+		limit = l
+	}
+
+	viewer, err := ctrl.viewer(ctx)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	// private fields are searched only for viewers who can see them
+	results, err := ctrl.UserBusiness.Search(ctx.Request.Context(), query.Get("q"), limit, viewer.Admin)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, views.NewUserSearchResults(results, viewer))
+}
+
+// CreatePostAction creates new user
+// @Summary This action creates new user in data store
+// @Accept json
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param user body requests.UserCreate true "User"
+// @Success 201 {object} views.User
+// @Failure 400 {object} models.ResponseError
+// @Router /users/ [post]
+func (ctrl *UsersController) CreatePostAction(ctx *flow.Context) {
+	req := new(requests.UserCreate)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	user, err := ctrl.UserBusiness.Create(ctx.Request.Context(), req.Email, req.FirstName, req.LastName, req.ProfileImage, req.BirthDate, req.Bio, req.PhoneNumber, req.Country, req.State, req.Area, req.City, req.Address, req.PostCode)
+	if err != nil {
+		ctrl.renderBusinessError(ctx, err)
+		return
+	}
+
+	ctrl.renderUser(ctx, http.StatusCreated, user)
+}
+
+// ReplacePutAction replaces user attributes
+// @Summary This action replaces all user attributes, omitted attributes are cleared
+// @Accept json
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param user body requests.UserUpdate true "User"
+// @Success 200 {object} views.User
+// @Failure 400 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Router /users/{id} [put]
+func (ctrl *UsersController) ReplacePutAction(ctx *flow.Context) {
+	ctrl.update(ctx, true)
+}
+
+// UpdatePatchAction updates user attributes
+// @Summary This action updates only user attributes present in request body
+// @Accept json
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param user body requests.UserUpdate true "User"
+// @Success 200 {object} views.User
+// @Failure 400 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Router /users/{id} [patch]
+func (ctrl *UsersController) UpdatePatchAction(ctx *flow.Context) {
+	ctrl.update(ctx, false)
+}
+
+// DestroyDeleteAction deletes user
+// @Summary This action soft deletes user with given id, user can be restored until purged
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} flow.VM
+// @Failure 400 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Router /users/{id} [delete]
+func (ctrl *UsersController) DestroyDeleteAction(ctx *flow.Context) {
+	id, err := ctrl.ParamID(ctx, "id")
+	if err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	if err := ctrl.UserBusiness.Delete(ctx.Request.Context(), id); err != nil {
+		ctrl.renderBusinessError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, flow.VM{
+		"id": id,
 	})
 }
+
+// RestorePostAction restores soft deleted user
+// @Summary This action restores soft deleted user with given id
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} views.User
+// @Failure 400 {object} models.ResponseError
+// @Failure 403 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Router /users/{id}/restore [post]
+func (ctrl *UsersController) RestorePostAction(ctx *flow.Context) {
+	id, err := ctrl.ParamID(ctx, "id")
+	if err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	user, err := ctrl.UserBusiness.Restore(ctx.Request.Context(), id)
+	if err != nil {
+		ctrl.renderBusinessError(ctx, err)
+		return
+	}
+
+	ctrl.renderUser(ctx, http.StatusOK, user)
+}
+
+// PurgeDeleteAction permanently deletes user
+// @Summary This action permanently deletes user with given id, whether it is soft deleted or not
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} flow.VM
+// @Failure 400 {object} models.ResponseError
+// @Failure 403 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Router /users/{id}/purge [delete]
+func (ctrl *UsersController) PurgeDeleteAction(ctx *flow.Context) {
+	id, err := ctrl.ParamID(ctx, "id")
+	if err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	if err := ctrl.UserBusiness.Purge(ctx.Request.Context(), id); err != nil {
+		ctrl.renderBusinessError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, flow.VM{
+		"id": id,
+	})
+}
+
+// InviteesGetAction returns tree of users invited by user
+// @Summary This action returns users invited by given user and, up to given depth, users they invited
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param depth query int false "Number of tree levels to walk" default(1)
+// @Success 200 {array} views.Invitee
+// @Failure 400 {object} models.ResponseError
+// @Failure 403 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Router /users/{id}/invitees [get]
+func (ctrl *UsersController) InviteesGetAction(ctx *flow.Context) {
+	id, err := ctrl.ParamID(ctx, "id")
+	if err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	depth := 1
+	if v := ctx.Request.URL.Query().Get("depth"); v != "" {
+		if depth, err = strconv.Atoi(v); err != nil {
+			ctrl.RenderBadRequestError(ctx, errors.Wrap(err, "invalid depth"))
+			return
+		}
+	}
+
+	invitees, err := ctrl.UserBusiness.GetInvitees(ctx.Request.Context(), id, depth)
+	if err != nil {
+		ctrl.renderBusinessError(ctx, err)
+		return
+	}
+
+	viewer, err := ctrl.viewer(ctx)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, views.NewInvitees(invitees, viewer))
+}
+
+// ActivatePostAction activates user account
+// @Summary This action activates inactive user account so it can authenticate
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} views.User
+// @Failure 403 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Router /users/{id}/activate [post]
+func (ctrl *UsersController) ActivatePostAction(ctx *flow.Context) {
+	ctrl.changeStatus(ctx, func(actorID uint64, id uint64) (*models.User, error) {
+		return ctrl.UserBusiness.Activate(ctx.Request.Context(), actorID, id)
+	})
+}
+
+// DeactivatePostAction deactivates user account
+// @Summary This action deactivates active or suspended user account, request body is optional
+// @Accept json
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param deactivation body requests.UserDeactivate false "Deactivation"
+// @Success 200 {object} views.User
+// @Failure 400 {object} models.ResponseError
+// @Failure 403 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Router /users/{id}/deactivate [post]
+func (ctrl *UsersController) DeactivatePostAction(ctx *flow.Context) {
+	req := new(requests.UserDeactivate)
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.BindJSON(req); err != nil {
+			ctrl.RenderBadRequestError(ctx, err)
+			return
+		}
+	}
+
+	ctrl.changeStatus(ctx, func(actorID uint64, id uint64) (*models.User, error) {
+		return ctrl.UserBusiness.Deactivate(ctx.Request.Context(), actorID, id, req.Reason)
+	})
+}
+
+// SuspendPostAction suspends user account
+// @Summary This action suspends user account until given time, or until reactivation when until is omitted
+// @Accept json
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param suspension body requests.UserSuspend true "Suspension"
+// @Success 200 {object} views.User
+// @Failure 400 {object} models.ResponseError
+// @Failure 403 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Router /users/{id}/suspend [post]
+func (ctrl *UsersController) SuspendPostAction(ctx *flow.Context) {
+	req := new(requests.UserSuspend)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	ctrl.changeStatus(ctx, func(actorID uint64, id uint64) (*models.User, error) {
+		return ctrl.UserBusiness.Suspend(ctx.Request.Context(), actorID, id, req.Reason, req.Until)
+	})
+}
+
+// ReactivatePostAction lifts user account suspension
+// @Summary This action lifts suspension of user account
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} views.User
+// @Failure 403 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Failure 409 {object} models.ResponseError
+// @Router /users/{id}/reactivate [post]
+func (ctrl *UsersController) ReactivatePostAction(ctx *flow.Context) {
+	ctrl.changeStatus(ctx, func(actorID uint64, id uint64) (*models.User, error) {
+		return ctrl.UserBusiness.Reactivate(ctx.Request.Context(), actorID, id)
+	})
+}
+
+// StatusTransitionsGetAction returns user account status history
+// @Summary This action returns account status transitions of user with actor and time, newest first
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {array} models.UserStatusTransition
+// @Failure 403 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Router /users/{id}/status-transitions [get]
+func (ctrl *UsersController) StatusTransitionsGetAction(ctx *flow.Context) {
+	id, err := ctrl.ParamID(ctx, "id")
+	if err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	transitions, err := ctrl.UserBusiness.GetStatusTransitions(ctx.Request.Context(), id)
+	if err != nil {
+		ctrl.renderBusinessError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, transitions)
+}
+
+// RolesGetAction returns roles assigned to user
+// @Summary This action returns list of roles assigned to user
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {array} models.Role
+// @Failure 403 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Router /users/{id}/roles [get]
+func (ctrl *UsersController) RolesGetAction(ctx *flow.Context) {
+	id, err := ctrl.ParamID(ctx, "id")
+	if err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	roles, err := ctrl.RoleBusiness.GetUserRoles(ctx.Request.Context(), id)
+	if err != nil {
+		ctrl.renderBusinessError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, roles)
+}
+
+// RolesPutAction assigns role to user
+// @Summary This action assigns role to user and returns all user roles
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param role path string true "Role name"
+// @Success 200 {array} models.Role
+// @Failure 403 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Router /users/{id}/roles/{role} [put]
+func (ctrl *UsersController) RolesPutAction(ctx *flow.Context) {
+	id, err := ctrl.ParamID(ctx, "id")
+	if err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	roles, err := ctrl.RoleBusiness.AssignRole(ctx.Request.Context(), id, ctx.Param("role"))
+	if err != nil {
+		ctrl.renderBusinessError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, roles)
+}
+
+// RolesDeleteAction revokes role from user
+// @Summary This action revokes role from user and returns remaining user roles
+// @Produce json
+// @Tags users
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param role path string true "Role name"
+// @Success 200 {array} models.Role
+// @Failure 403 {object} models.ResponseError
+// @Failure 404 {object} models.ResponseError
+// @Router /users/{id}/roles/{role} [delete]
+func (ctrl *UsersController) RolesDeleteAction(ctx *flow.Context) {
+	id, err := ctrl.ParamID(ctx, "id")
+	if err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	roles, err := ctrl.RoleBusiness.RevokeRole(ctx.Request.Context(), id, ctx.Param("role"))
+	if err != nil {
+		ctrl.renderBusinessError(ctx, err)
+		return
+	}
+
+	ctrl.RenderSuccess(ctx, roles)
+}
+
+// update binds update request and applies it to user with id from route
+//
+// when replace is true all omitted attributes are cleared
+func (ctrl *UsersController) update(ctx *flow.Context, replace bool) {
+	id, err := ctrl.ParamID(ctx, "id")
+	if err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	req := new(requests.UserUpdate)
+	if err := ctx.BindJSON(req); err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	if replace {
+		req.Replace()
+	}
+
+	user, err := ctrl.UserBusiness.Update(ctx.Request.Context(), id, req.FirstName, req.LastName, req.ProfileImage, req.BirthDate, req.Bio, req.PhoneNumber, req.Country, req.State, req.Area, req.City, req.Address, req.PostCode)
+	if err != nil {
+		ctrl.renderBusinessError(ctx, err)
+		return
+	}
+
+	ctrl.renderUser(ctx, http.StatusOK, user)
+}
+
+// changeStatus applies account status change performed by authenticated user to user with id from route
+func (ctrl *UsersController) changeStatus(ctx *flow.Context, change func(actorID uint64, id uint64) (*models.User, error)) {
+	id, err := ctrl.ParamID(ctx, "id")
+	if err != nil {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+
+	actor, _ := middlewares.CurrentUser(ctx)
+
+	user, err := change(actor.ID, id)
+	if err != nil {
+		ctrl.renderBusinessError(ctx, err)
+		return
+	}
+
+	ctrl.renderUser(ctx, http.StatusOK, user)
+}
+
+// viewer returns authenticated user responses are rendered for
+//
+// users granted `users:private` permission are admins and see private fields of all users
+func (ctrl *UsersController) viewer(ctx *flow.Context) (views.Viewer, error) {
+	current, _ := middlewares.CurrentUser(ctx)
+
+	admin, err := ctrl.RoleBusiness.HasPermission(ctx.Request.Context(), current.ID, "users:private")
+	if err != nil {
+		return views.Viewer{}, err
+	}
+
+	return views.Viewer{UserID: current.ID, Admin: admin}, nil
+}
+
+// renderUser renders user visible to authenticated user with given http status
+func (ctrl *UsersController) renderUser(ctx *flow.Context, status int, user *models.User) {
+	viewer, err := ctrl.viewer(ctx)
+	if err != nil {
+		ctrl.RenderDomainError(ctx, err)
+		return
+	}
+
+	ctrl.Render(true, status, ctx, views.NewUser(user, viewer), nil)
+}
+
+// renderBusinessError renders 400 for invalid paging parameters, and error with status matching its kind otherwise
+func (ctrl *UsersController) renderBusinessError(ctx *flow.Context, err error) {
+	switch err.(type) {
+	case *paging.SortError, *paging.FilterError:
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+	if err == paging.ErrInvalidCursor {
+		ctrl.RenderBadRequestError(ctx, err)
+		return
+	}
+	ctrl.RenderDomainError(ctx, err)
+}
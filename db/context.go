@@ -0,0 +1,209 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/jinzhu/gorm"
+)
+
+// requestIDKey is context key request ID is stored under
+type requestIDKey struct{}
+
+// logging holds configured detailed logging of stores bound to context
+var logging bool
+
+// WithRequestID returns copy of ctx carrying request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns request ID carried by ctx, or empty string
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// scopeKey is context key store scope is stored under
+type scopeKey struct{}
+
+// scope caches stores bound to contexts of single request
+type scope struct {
+	mu     sync.Mutex
+	stores map[scopedStore]Store
+}
+
+// scopedStore is key of store bound to context
+type scopedStore struct {
+	ctx   context.Context
+	store Store
+}
+
+// WithScope returns copy of ctx in which WithContext binds each store to context
+// only once, so repositories called during request share bound store
+func WithScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scopeKey{}, &scope{stores: make(map[scopedStore]Store)})
+}
+
+// WithContext returns store executing statements with ctx, so they are
+// canceled when ctx is canceled or its deadline passes
+//
+// when ctx carries transaction started by UnitOfWork, returned store executes
+// statements in that transaction instead of given store.
+// Search conditions of store are not kept, store should be bound before query is built.
+//
+// gorm cannot replace connection of existing store, so bound store is opened on
+// connection wrapper. It is opened once per context in scope created by WithScope,
+// and on every call outside of it. Bound store is configured as application store,
+// callbacks have to be registered on gorm.DefaultCallback to run in bound stores.
+// DB of bound store returns connection pool of given store, or nil in transaction,
+// instead of panicking as gorm does
+func WithContext(ctx context.Context, store Store) Store {
+	if ctx == nil {
+		return store
+	}
+
+	if tx, ok := transactionFromContext(ctx); ok {
+		return tx.store
+	}
+
+	sc, ok := ctx.Value(scopeKey{}).(*scope)
+	if !ok {
+		return bind(ctx, store)
+	}
+
+	key := scopedStore{ctx: ctx, store: store}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	bound, ok := sc.stores[key]
+	if !ok {
+		bound = bind(ctx, store)
+		sc.stores[key] = bound
+	}
+	return bound
+}
+
+// bind opens store executing statements of given store with ctx
+func bind(ctx context.Context, store Store) Store {
+	var gdb *gorm.DB
+	switch s := store.(type) {
+	case *gorm.DB:
+		gdb = s
+	case *boundStore:
+		gdb = s.gormDB
+	default:
+		return store
+	}
+
+	var (
+		conn gorm.SQLCommon
+		pool *sql.DB
+	)
+	switch c := gdb.CommonDB().(type) {
+	case *sql.DB:
+		conn, pool = &contextDB{ctx: ctx, db: c}, c
+	case *contextDB:
+		conn, pool = &contextDB{ctx: ctx, db: c.db}, c.db
+	case *sql.Tx:
+		conn = &contextTx{ctx: ctx, tx: c}
+	case *contextTx:
+		conn = &contextTx{ctx: ctx, tx: c.tx}
+	case *routedDB:
+		conn, pool = c.withContext(ctx), c.primary
+	default:
+		return store
+	}
+
+	bound, err := gorm.Open(gdb.Dialect().GetName(), conn)
+	if err != nil {
+		return store
+	}
+
+	return &boundStore{gormDB: configure(bound), pool: pool}
+}
+
+// configure applies settings of application store to gdb
+func configure(gdb *gorm.DB) *gorm.DB {
+	return gdb.LogMode(logging)
+}
+
+// gormDB names embedded store of boundStore, so boundStore can define DB method
+type gormDB = gorm.DB
+
+// boundStore is store bound to context
+type boundStore struct {
+	*gormDB
+	pool *sql.DB
+}
+
+// DB returns connection pool store was bound from, or nil for store bound to transaction
+func (s *boundStore) DB() *sql.DB {
+	return s.pool
+}
+
+// contextDB executes statements on database connection pool with context
+//
+// it implements gorm.SQLCommon, and Begin so gorm can start transactions bound to context
+type contextDB struct {
+	ctx context.Context
+	db  *sql.DB
+}
+
+func (c *contextDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.db.ExecContext(c.ctx, query, args...)
+}
+
+func (c *contextDB) Prepare(query string) (*sql.Stmt, error) {
+	return c.db.PrepareContext(c.ctx, query)
+}
+
+func (c *contextDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.db.QueryContext(c.ctx, query, args...)
+}
+
+func (c *contextDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.db.QueryRowContext(c.ctx, query, args...)
+}
+
+func (c *contextDB) Begin() (*sql.Tx, error) {
+	return c.db.BeginTx(c.ctx, nil)
+}
+
+func (c *contextDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return c.db.BeginTx(ctx, opts)
+}
+
+// contextTx executes statements in transaction with context
+//
+// it implements gorm.SQLCommon, and Commit and Rollback so gorm can finish transaction
+type contextTx struct {
+	ctx context.Context
+	tx  *sql.Tx
+}
+
+func (c *contextTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.tx.ExecContext(c.ctx, query, args...)
+}
+
+func (c *contextTx) Prepare(query string) (*sql.Stmt, error) {
+	return c.tx.PrepareContext(c.ctx, query)
+}
+
+func (c *contextTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.tx.QueryContext(c.ctx, query, args...)
+}
+
+func (c *contextTx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.tx.QueryRowContext(c.ctx, query, args...)
+}
+
+func (c *contextTx) Commit() error {
+	return c.tx.Commit()
+}
+
+func (c *contextTx) Rollback() error {
+	return c.tx.Rollback()
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithContext_DB(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gdb, err := gorm.Open("mysql", sqlDB)
+	require.NoError(t, err)
+
+	bound := WithContext(context.Background(), gdb)
+	assert.Equal(t, sqlDB, bound.DB())
+	assert.Equal(t, sqlDB, WithContext(context.Background(), bound).DB())
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	uow := &unitOfWork{store: gdb}
+	err = uow.WithTransaction(context.Background(), func(ctx context.Context, tx Store) error {
+		assert.Nil(t, tx.DB())
+		assert.Nil(t, WithContext(ctx, gdb).DB())
+		return assert.AnError
+	})
+
+	assert.Equal(t, assert.AnError, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithContext_Canceled(t *testing.T) {
+	sqlDB, _, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gdb, err := gorm.Open("mysql", sqlDB)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = WithContext(ctx, gdb).Exec(updateQuery, true, 1).Error
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestWithContext_Scope(t *testing.T) {
+	sqlDB, _, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gdb, err := gorm.Open("mysql", sqlDB)
+	require.NoError(t, err)
+
+	ctx := WithScope(context.Background())
+	bound := WithContext(ctx, gdb)
+	assert.True(t, bound == WithContext(ctx, gdb))
+
+	timeout, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	assert.False(t, bound == WithContext(timeout, gdb))
+
+	assert.False(t, WithContext(context.Background(), gdb) == WithContext(context.Background(), gdb))
+}
+
+func TestWithContext_TransactionStore(t *testing.T) {
+	uow, mock := newUnitOfWorkMock(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(updateQuery)).WithArgs(true, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := uow.WithTransaction(context.Background(), func(ctx context.Context, tx Store) error {
+		assert.True(t, tx == WithContext(ctx, uow.store))
+		return update(ctx, uow)
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
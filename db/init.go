@@ -1,7 +1,10 @@
 package db
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -12,11 +15,21 @@ import (
 
 	// initialize mysql driver
 	_ "github.com/go-sql-driver/mysql"
+	// initialize postgres driver
+	_ "github.com/lib/pq"
+	// initialize sqlite3 driver
+	_ "github.com/mattn/go-sqlite3"
 )
 
 // application data store
 var store Store
 
+// primary database connection, store routes reads to replicas when they are configured
+var primary *gorm.DB
+
+// read replicas of primary database
+var replicas *replicaSet
+
 // Init loads models for given application
 func Init(app *flow.App) {
 	cfg := app.AppConfig.(config.AppConfig)
@@ -47,6 +60,10 @@ func Init(app *flow.App) {
 
 	// SetMaxOpenConns sets the maximum number of open connections to the database
 	maxConn = dbCfg.DbMaxOpenConns
+	if dbCfg.DbDialect == "sqlite3" {
+		// SQLite locks database file for writes, concurrent writers fail with `database is locked`
+		maxConn = 1
+	}
 	db.DB().SetMaxOpenConns(maxConn)
 
 	// SetConnMaxLifetime sets the maximum amount of time a connection may be reused.
@@ -55,14 +72,39 @@ func Init(app *flow.App) {
 	db.DB().SetConnMaxLifetime(duration)
 
 	// Enable Logger, show detailed log
-	db.LogMode(dbCfg.DbLogging)
+	logging = dbCfg.DbLogging
+	configure(db)
 
 	// assign db object to application store
+	primary = db
 	store = db
 
+	// route reads to replicas
+	if len(dbCfg.DbReplicas) > 0 {
+		var dbs []*sql.DB
+		for _, dsn := range dbCfg.DbReplicas {
+			replica, err := sql.Open(dbCfg.DbDialect, dsn)
+			if err != nil {
+				app.Logger.Fatal(err.Error())
+			}
+
+			// replicas share connection pool settings of primary
+			replica.SetMaxIdleConns(dbCfg.DbMaxIdleConns)
+			replica.SetMaxOpenConns(dbCfg.DbMaxOpenConns)
+			replica.SetConnMaxLifetime(duration)
+
+			dbs = append(dbs, replica)
+		}
+
+		store = routeReplicas(app, db, dbs)
+	}
+
 	// register db store object to DI
 	app.Register(store)
 
+	// register unit of work running transactions on store
+	app.Register(NewUnitOfWork(app))
+
 	// prepare DB migrations
 	migrationsPath := cfg.DbMigrationsPath
 	autoMigrate := cfg.DbMigrationsAutorun
@@ -72,19 +114,29 @@ func Init(app *flow.App) {
 
 	app.Logger.Info("Start application migrations...")
 	// execute migrations
-	fm := migrator.NewFileMigrator(migrationsPath, dbCfg.DbDialect, db.DB())
-	if err = fm.Up(); err != nil {
+	if err = Migrate(db.DB(), dbCfg.DbDialect, migrationsPath); err != nil {
 		app.Logger.Fatal(err.Error())
 	}
 	app.Logger.Info("End application migrations.")
 }
 
+// Migrate executes pending migrations of dialect
+//
+// every dialect has its own migrations directory under migrationsPath, e.g. `migrations/postgres`
+func Migrate(conn *sql.DB, dialect string, migrationsPath string) error {
+	fm := migrator.NewFileMigrator(filepath.Join(migrationsPath, dialect), dialect, conn)
+	return fm.Up()
+}
+
 // Close closes Database connection
 //
 // it is suitable for defer models.CloseDB()
 func Close() error {
-	if store != nil {
-		return store.DB().Close()
+	if replicas != nil {
+		replicas.close()
+	}
+	if primary != nil {
+		return primary.DB().Close()
 	}
 	return nil
 }
@@ -93,3 +145,29 @@ func Close() error {
 func Connection() Store {
 	return store
 }
+
+// routeReplicas returns store executing reads on replicas and writes on primary
+//
+// replicas are pinged periodically, failed replica is evicted until it responds again
+func routeReplicas(app *flow.App, db *gorm.DB, dbs []*sql.DB) Store {
+	cfg := app.AppConfig.(config.AppConfig)
+
+	set, err := newReplicaSet(dbs, cfg.DbReplicaBalancer, app.Logger)
+	if err != nil {
+		app.Logger.Fatal(err.Error())
+	}
+	replicas = set
+
+	interval := time.Duration(cfg.DbReplicaHealthInterval) * time.Second
+	if interval > 0 {
+		set.check(interval)
+		go set.watch(interval)
+	}
+
+	routed, err := gorm.Open(db.Dialect().GetName(), &routedDB{ctx: context.Background(), primary: db.DB(), replicas: set})
+	if err != nil {
+		app.Logger.Fatal(err.Error())
+	}
+
+	return configure(routed)
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"fmt"
+
+	migratordb "github.com/go-flow/migrator/db"
+	"github.com/go-flow/migrator/dialect"
+)
+
+// migrator ships only `mysql` dialect with migration table support,
+// `postgres` and `sqlite3` dialects are replaced with complete implementations
+func init() {
+	dialect.RegisterDialect("postgres", &postgresMigrations{})
+	dialect.RegisterDialect("sqlite3", &sqlite3Migrations{})
+}
+
+// postgresMigrations is migrator dialect for PostgreSQL
+type postgresMigrations struct {
+	db migratordb.Store
+}
+
+func (postgresMigrations) Name() string {
+	return "postgres"
+}
+
+func (c *postgresMigrations) SetDB(db migratordb.Store) {
+	c.db = db
+}
+
+func (c *postgresMigrations) DB() migratordb.Store {
+	return c.db
+}
+
+func (c *postgresMigrations) CurrentDatabase() (name string) {
+	c.db.QueryRow("SELECT CURRENT_DATABASE()").Scan(&name)
+	return
+}
+
+func (c *postgresMigrations) HasTable(tableName string) bool {
+	var count int
+	c.db.QueryRow("SELECT count(*) FROM INFORMATION_SCHEMA.tables WHERE table_name = $1 AND table_type = 'BASE TABLE' AND table_schema = CURRENT_SCHEMA()", tableName).Scan(&count)
+	return count > 0
+}
+
+func (c *postgresMigrations) MigrationExists(version string, tableName string) (bool, error) {
+	var count int
+	err := c.db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE version = $1", tableName), version).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (c *postgresMigrations) CreateMigrationTable(tableName string) error {
+	_, err := c.db.Exec(fmt.Sprintf(`CREATE TABLE %s (
+		version VARCHAR(14) NOT NULL,
+		name VARCHAR(255) NULL);
+		CREATE UNIQUE INDEX schema_version_idx ON %s (version ASC);`, tableName, tableName))
+	return err
+}
+
+func (c *postgresMigrations) CountRecords(tableName string) (int, error) {
+	var count int
+	err := c.db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)).Scan(&count)
+	return count, err
+}
+
+func (c *postgresMigrations) SaveMigration(tableName string, version string, name string) error {
+	_, err := c.db.Exec(fmt.Sprintf("INSERT INTO %s (version, name) VALUES ($1, $2)", tableName), version, name)
+	return err
+}
+
+func (c *postgresMigrations) RemoveMigration(tableName string, version string) error {
+	_, err := c.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE version = $1", tableName), version)
+	return err
+}
+
+// sqlite3Migrations is migrator dialect for SQLite
+type sqlite3Migrations struct {
+	db migratordb.Store
+}
+
+func (sqlite3Migrations) Name() string {
+	return "sqlite3"
+}
+
+func (c *sqlite3Migrations) SetDB(db migratordb.Store) {
+	c.db = db
+}
+
+func (c *sqlite3Migrations) DB() migratordb.Store {
+	return c.db
+}
+
+// CurrentDatabase returns file name of main database, empty for in-memory database
+func (c *sqlite3Migrations) CurrentDatabase() (name string) {
+	var (
+		seq    int
+		schema string
+	)
+	c.db.QueryRow("PRAGMA database_list").Scan(&seq, &schema, &name)
+	return
+}
+
+func (c *sqlite3Migrations) HasTable(tableName string) bool {
+	var count int
+	c.db.QueryRow("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = ?", tableName).Scan(&count)
+	return count > 0
+}
+
+func (c *sqlite3Migrations) MigrationExists(version string, tableName string) (bool, error) {
+	var count int
+	err := c.db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE version = ?", tableName), version).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (c *sqlite3Migrations) CreateMigrationTable(tableName string) error {
+	_, err := c.db.Exec(fmt.Sprintf(`CREATE TABLE %s (
+		version VARCHAR(14) NOT NULL,
+		name VARCHAR(255) NULL);
+		CREATE UNIQUE INDEX schema_version_idx ON %s (version ASC);`, tableName, tableName))
+	return err
+}
+
+func (c *sqlite3Migrations) CountRecords(tableName string) (int, error) {
+	var count int
+	err := c.db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)).Scan(&count)
+	return count, err
+}
+
+func (c *sqlite3Migrations) SaveMigration(tableName string, version string, name string) error {
+	_, err := c.db.Exec(fmt.Sprintf("INSERT INTO %s (version, name) VALUES (?, ?)", tableName), version, name)
+	return err
+}
+
+func (c *sqlite3Migrations) RemoveMigration(tableName string, version string) error {
+	_, err := c.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE version = ?", tableName), version)
+	return err
+}
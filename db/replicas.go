@@ -0,0 +1,250 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-flow/flow/log"
+)
+
+// replica balancing strategies
+const (
+	// BalancerRoundRobin routes reads to healthy replicas in turn
+	BalancerRoundRobin = "round-robin"
+
+	// BalancerLeastConnections routes reads to healthy replica with fewest connections in use
+	BalancerLeastConnections = "least-connections"
+)
+
+// routingKey is context key read routing of context is stored under
+type routingKey struct{}
+
+// routing decides where reads of context are executed
+type routing struct {
+	// primary routes all reads to primary
+	primary bool
+
+	// sticky routes reads to primary once context executed write
+	sticky bool
+	wrote  int32
+}
+
+// ForcePrimary returns copy of ctx whose reads are executed on primary
+func ForcePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, routingKey{}, &routing{primary: true})
+}
+
+// WithReadYourWrites returns copy of ctx whose reads are executed on primary
+// once write was executed with it, so request reads its own changes
+// regardless of replication lag
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, routingKey{}, &routing{sticky: true})
+}
+
+// readsFromPrimary reports whether reads of ctx are routed to primary
+func readsFromPrimary(ctx context.Context) bool {
+	r, ok := ctx.Value(routingKey{}).(*routing)
+	return ok && (r.primary || atomic.LoadInt32(&r.wrote) == 1)
+}
+
+// markWrite records write executed with ctx
+func markWrite(ctx context.Context) {
+	if r, ok := ctx.Value(routingKey{}).(*routing); ok && r.sticky {
+		atomic.StoreInt32(&r.wrote, 1)
+	}
+}
+
+// replica is read-only database connection pool
+type replica struct {
+	name    string
+	db      *sql.DB
+	healthy int32
+}
+
+// isHealthy reports whether replica passed last health check
+func (r *replica) isHealthy() bool {
+	return atomic.LoadInt32(&r.healthy) == 1
+}
+
+// replicaSet balances reads over healthy replicas
+type replicaSet struct {
+	replicas []*replica
+	balancer string
+	next     uint32
+	logger   log.Logger
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// newReplicaSet creates replica set of given connection pools, replicas are healthy until first check
+func newReplicaSet(dbs []*sql.DB, balancer string, logger log.Logger) (*replicaSet, error) {
+	if balancer != BalancerRoundRobin && balancer != BalancerLeastConnections {
+		return nil, fmt.Errorf("unsupported replica balancer `%s`", balancer)
+	}
+
+	set := &replicaSet{balancer: balancer, logger: logger, stop: make(chan struct{})}
+	for i, db := range dbs {
+		set.replicas = append(set.replicas, &replica{name: fmt.Sprintf("replica-%d", i+1), db: db, healthy: 1})
+	}
+
+	return set, nil
+}
+
+// pick returns replica reads are executed on, or nil when no replica is healthy
+func (set *replicaSet) pick() *sql.DB {
+	switch set.balancer {
+	case BalancerLeastConnections:
+		var picked *replica
+		inUse := 0
+		for _, r := range set.replicas {
+			if !r.isHealthy() {
+				continue
+			}
+			if n := r.db.Stats().InUse; picked == nil || n < inUse {
+				picked, inUse = r, n
+			}
+		}
+		if picked != nil {
+			return picked.db
+		}
+	default:
+		n := len(set.replicas)
+		start := int(atomic.AddUint32(&set.next, 1) - 1)
+		for i := 0; i < n; i++ {
+			if r := set.replicas[(start+i)%n]; r.isHealthy() {
+				return r.db
+			}
+		}
+	}
+
+	return nil
+}
+
+// check pings every replica, evicting failed replicas and returning recovered ones
+func (set *replicaSet) check(timeout time.Duration) {
+	for _, r := range set.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := r.db.PingContext(ctx)
+		cancel()
+
+		if err != nil {
+			if atomic.SwapInt32(&r.healthy, 0) == 1 {
+				set.logger.Warnf("database %s evicted: %v", r.name, err)
+			}
+			continue
+		}
+
+		if atomic.SwapInt32(&r.healthy, 1) == 0 {
+			set.logger.Infof("database %s returned", r.name)
+		}
+	}
+}
+
+// watch checks replica health periodically until closed
+func (set *replicaSet) watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-set.stop:
+			return
+		case <-ticker.C:
+			set.check(interval)
+		}
+	}
+}
+
+// close stops health checks and closes replica connection pools
+func (set *replicaSet) close() error {
+	set.once.Do(func() { close(set.stop) })
+
+	var err error
+	for _, r := range set.replicas {
+		if cerr := r.db.Close(); cerr != nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// routedDB executes reads on replicas and writes on primary
+//
+// it implements gorm.SQLCommon, and Begin so transactions run on primary
+type routedDB struct {
+	ctx      context.Context
+	primary  *sql.DB
+	replicas *replicaSet
+}
+
+// withContext returns copy of routedDB executing statements with ctx
+func (c *routedDB) withContext(ctx context.Context) *routedDB {
+	return &routedDB{ctx: ctx, primary: c.primary, replicas: c.replicas}
+}
+
+// reader returns connection pool query is executed on
+//
+// only plain selects are routed to replicas, locking reads and
+// reads of contexts forced to primary are executed on primary
+func (c *routedDB) reader(query string) *sql.DB {
+	if readsFromPrimary(c.ctx) || !isPlainSelect(query) {
+		return c.primary
+	}
+
+	if db := c.replicas.pick(); db != nil {
+		return db
+	}
+	return c.primary
+}
+
+func (c *routedDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	markWrite(c.ctx)
+	return c.primary.ExecContext(c.ctx, query, args...)
+}
+
+func (c *routedDB) Prepare(query string) (*sql.Stmt, error) {
+	if !isPlainSelect(query) {
+		markWrite(c.ctx)
+	}
+	return c.primary.PrepareContext(c.ctx, query)
+}
+
+// Query executes query, writes returning rows such as INSERT ... RETURNING are recorded as writes
+func (c *routedDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	if !isPlainSelect(query) {
+		markWrite(c.ctx)
+	}
+	return c.reader(query).QueryContext(c.ctx, query, args...)
+}
+
+// QueryRow executes query, writes returning row such as INSERT ... RETURNING are recorded as writes
+func (c *routedDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	if !isPlainSelect(query) {
+		markWrite(c.ctx)
+	}
+	return c.reader(query).QueryRowContext(c.ctx, query, args...)
+}
+
+func (c *routedDB) Begin() (*sql.Tx, error) {
+	return c.BeginTx(c.ctx, nil)
+}
+
+func (c *routedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	markWrite(ctx)
+	return c.primary.BeginTx(ctx, opts)
+}
+
+// isPlainSelect reports whether query is select which does not lock rows
+func isPlainSelect(query string) bool {
+	q := strings.ToUpper(strings.TrimSpace(query))
+	if !strings.HasPrefix(q, "SELECT") {
+		return false
+	}
+	return !strings.Contains(q, " FOR UPDATE") && !strings.Contains(q, " FOR SHARE") && !strings.Contains(q, " LOCK IN SHARE MODE")
+}
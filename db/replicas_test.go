@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-flow/flow/log"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const selectQuery = "SELECT * FROM `users` WHERE (id = ?)"
+
+type replicaUser struct {
+	ID   int
+	Name string
+}
+
+func (replicaUser) TableName() string {
+	return "users"
+}
+
+// newRoutedMock creates store routing reads to given number of sqlmock replicas
+func newRoutedMock(t *testing.T, balancer string, n int) (Store, *replicaSet, sqlmock.Sqlmock, []sqlmock.Sqlmock) {
+	primaryDB, primaryMock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	var dbs []*sql.DB
+	var mocks []sqlmock.Sqlmock
+	for i := 0; i < n; i++ {
+		replicaDB, replicaMock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		require.NoError(t, err)
+		dbs = append(dbs, replicaDB)
+		mocks = append(mocks, replicaMock)
+	}
+
+	set, err := newReplicaSet(dbs, balancer, log.New(log.Configuration{}))
+	require.NoError(t, err)
+
+	gdb, err := gorm.Open("mysql", &routedDB{ctx: context.Background(), primary: primaryDB, replicas: set})
+	require.NoError(t, err)
+
+	return gdb, set, primaryMock, mocks
+}
+
+// find reads user the way repositories do, binding store to ctx
+func find(ctx context.Context, store Store) error {
+	var u replicaUser
+	return WithContext(ctx, store).Where("id = ?", 1).Find(&u).Error
+}
+
+func userRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "john")
+}
+
+func TestRoutedDB_ReadsFromReplicaWritesToPrimary(t *testing.T) {
+	store, _, primaryMock, replicaMocks := newRoutedMock(t, BalancerRoundRobin, 1)
+
+	replicaMocks[0].ExpectQuery(regexp.QuoteMeta(selectQuery)).WithArgs(1).WillReturnRows(userRows())
+	primaryMock.ExpectExec(regexp.QuoteMeta(updateQuery)).WithArgs(true, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, find(context.Background(), store))
+	assert.NoError(t, WithContext(context.Background(), store).Exec(updateQuery, true, 1).Error)
+
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	assert.NoError(t, replicaMocks[0].ExpectationsWereMet())
+}
+
+func TestRoutedDB_LockingReadFromPrimary(t *testing.T) {
+	store, _, primaryMock, replicaMocks := newRoutedMock(t, BalancerRoundRobin, 1)
+
+	primaryMock.ExpectQuery("SELECT (.+) FOR UPDATE").WillReturnRows(userRows())
+
+	var u replicaUser
+	err := WithContext(context.Background(), store).Raw("SELECT * FROM users WHERE id = ? FOR UPDATE", 1).Scan(&u).Error
+
+	assert.NoError(t, err)
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	assert.NoError(t, replicaMocks[0].ExpectationsWereMet())
+}
+
+func TestRoutedDB_ForcePrimary(t *testing.T) {
+	store, _, primaryMock, replicaMocks := newRoutedMock(t, BalancerRoundRobin, 1)
+
+	primaryMock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WithArgs(1).WillReturnRows(userRows())
+
+	assert.NoError(t, find(ForcePrimary(context.Background()), store))
+
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	assert.NoError(t, replicaMocks[0].ExpectationsWereMet())
+}
+
+func TestRoutedDB_ReadYourWrites(t *testing.T) {
+	store, _, primaryMock, replicaMocks := newRoutedMock(t, BalancerRoundRobin, 1)
+	ctx := WithReadYourWrites(context.Background())
+
+	replicaMocks[0].ExpectQuery(regexp.QuoteMeta(selectQuery)).WillReturnRows(userRows())
+	primaryMock.ExpectExec(regexp.QuoteMeta(updateQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	primaryMock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WillReturnRows(userRows())
+
+	// reads before first write may use replica
+	assert.NoError(t, find(ctx, store))
+	assert.NoError(t, WithContext(ctx, store).Exec(updateQuery, true, 1).Error)
+	assert.NoError(t, find(ctx, store))
+
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	assert.NoError(t, replicaMocks[0].ExpectationsWereMet())
+}
+
+func TestRoutedDB_ReadYourWritesReturning(t *testing.T) {
+	store, _, primaryMock, replicaMocks := newRoutedMock(t, BalancerRoundRobin, 1)
+	ctx := WithReadYourWrites(context.Background())
+
+	// postgres dialect creates records with INSERT ... RETURNING executed through QueryRow
+	primaryMock.ExpectQuery("INSERT INTO users (.+) RETURNING id").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	primaryMock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WillReturnRows(userRows())
+
+	var id int
+	require.NoError(t, WithContext(ctx, store).Raw("INSERT INTO users (name) VALUES (?) RETURNING id", "john").Row().Scan(&id))
+	assert.Equal(t, 1, id)
+	assert.NoError(t, find(ctx, store))
+
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	assert.NoError(t, replicaMocks[0].ExpectationsWereMet())
+}
+
+func TestRoutedDB_RoundRobin(t *testing.T) {
+	store, _, primaryMock, replicaMocks := newRoutedMock(t, BalancerRoundRobin, 2)
+
+	for _, mock := range replicaMocks {
+		mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WillReturnRows(userRows())
+		mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WillReturnRows(userRows())
+	}
+
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, find(context.Background(), store))
+	}
+
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	for _, mock := range replicaMocks {
+		assert.NoError(t, mock.ExpectationsWereMet())
+	}
+}
+
+func TestRoutedDB_LeastConnections(t *testing.T) {
+	store, _, primaryMock, replicaMocks := newRoutedMock(t, BalancerLeastConnections, 2)
+
+	// first replica is busy with open result set
+	replicaMocks[0].ExpectQuery("SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	replicaMocks[1].ExpectQuery(regexp.QuoteMeta(selectQuery)).WillReturnRows(userRows())
+
+	rows, err := WithContext(context.Background(), store).Raw("SELECT 1").Rows()
+	require.NoError(t, err)
+	defer rows.Close()
+
+	assert.NoError(t, find(context.Background(), store))
+
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	for _, mock := range replicaMocks {
+		assert.NoError(t, mock.ExpectationsWereMet())
+	}
+}
+
+func TestRoutedDB_HealthCheck(t *testing.T) {
+	store, set, primaryMock, replicaMocks := newRoutedMock(t, BalancerRoundRobin, 1)
+
+	// failed replica is evicted, reads fall back to primary
+	replicaMocks[0].ExpectPing().WillReturnError(errors.New("connection refused"))
+	set.check(time.Second)
+	primaryMock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WillReturnRows(userRows())
+	assert.NoError(t, find(context.Background(), store))
+
+	// recovered replica returns
+	replicaMocks[0].ExpectPing()
+	set.check(time.Second)
+	replicaMocks[0].ExpectQuery(regexp.QuoteMeta(selectQuery)).WillReturnRows(userRows())
+	assert.NoError(t, find(context.Background(), store))
+
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	assert.NoError(t, replicaMocks[0].ExpectationsWereMet())
+}
+
+func TestNewReplicaSet_UnsupportedBalancer(t *testing.T) {
+	_, err := newReplicaSet(nil, "random", log.New(log.Configuration{}))
+	assert.Error(t, err)
+}
+
+func TestRoutedDB_TransactionOnPrimary(t *testing.T) {
+	store, _, primaryMock, replicaMocks := newRoutedMock(t, BalancerRoundRobin, 1)
+	uow := &unitOfWork{store: store}
+
+	primaryMock.ExpectBegin()
+	primaryMock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WillReturnRows(userRows())
+	primaryMock.ExpectExec(regexp.QuoteMeta(updateQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	primaryMock.ExpectCommit()
+
+	err := uow.WithTransaction(context.Background(), func(ctx context.Context, tx Store) error {
+		if err := find(ctx, store); err != nil {
+			return err
+		}
+		return update(ctx, uow)
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	assert.NoError(t, replicaMocks[0].ExpectationsWereMet())
+}
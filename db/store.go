@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/jinzhu/gorm"
@@ -29,6 +30,7 @@ type Store interface {
 	Related(value interface{}, foreignKeys ...string) *gorm.DB
 	Unscoped() *gorm.DB
 	Begin() *gorm.DB
+	BeginTx(ctx context.Context, opts *sql.TxOptions) *gorm.DB
 	Commit() *gorm.DB
 	Rollback() *gorm.DB
 	Table(name string) *gorm.DB
@@ -0,0 +1,173 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/config"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+// mysqlDeadlock is MySQL error number of ER_LOCK_DEADLOCK
+const mysqlDeadlock = 1213
+
+// postgresDeadlock is PostgreSQL error code of deadlock_detected
+const postgresDeadlock = "40P01"
+
+// UnitOfWork runs groups of repository operations in single database transaction
+type UnitOfWork interface {
+	// UnitOfWork ensures interface implementation
+	UnitOfWork() string
+
+	// WithTransaction runs fn in transaction, which is committed when fn returns nil
+	// and rolled back when fn returns error or panics
+	//
+	// ctx passed to fn carries transaction, repositories binding their store
+	// with WithContext execute statements in it. Calls nested in fn run in savepoints.
+	// Transaction is retried with backoff when it is chosen as deadlock victim,
+	// so fn must not have side effects outside of database
+	WithTransaction(ctx context.Context, fn func(ctx context.Context, tx Store) error) error
+}
+
+// NewUnitOfWork creates UnitOfWork implementation running transactions on application store
+//
+// it has to be called after Init
+func NewUnitOfWork(app *flow.App) UnitOfWork {
+	cfg := app.AppConfig.(config.AppConfig)
+
+	return &unitOfWork{
+		store:      store,
+		maxRetries: cfg.DbTxMaxRetries,
+		backoff:    time.Duration(cfg.DbTxRetryBackoff) * time.Millisecond,
+	}
+}
+
+// transaction is transaction carried by context
+type transaction struct {
+	store      Store
+	savepoints int
+}
+
+// transactionKey is context key transaction is stored under
+type transactionKey struct{}
+
+// transactionFromContext returns transaction carried by ctx
+func transactionFromContext(ctx context.Context) (*transaction, bool) {
+	tx, ok := ctx.Value(transactionKey{}).(*transaction)
+	return tx, ok
+}
+
+// unitOfWork struct that implements UnitOfWork interface
+type unitOfWork struct {
+	store      Store
+	maxRetries int
+	backoff    time.Duration
+}
+
+// UnitOfWork ensures interface implementation
+func (uow *unitOfWork) UnitOfWork() string {
+	return "unitOfWork"
+}
+
+// WithTransaction runs fn in transaction, or in savepoint when ctx already carries transaction
+func (uow *unitOfWork) WithTransaction(ctx context.Context, fn func(ctx context.Context, tx Store) error) error {
+	if tx, ok := transactionFromContext(ctx); ok {
+		return uow.savepoint(ctx, tx, fn)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := uow.transaction(ctx, fn)
+		if err == nil || !IsDeadlock(err) || attempt >= uow.maxRetries {
+			return err
+		}
+
+		// exponential backoff with jitter, so retried transactions do not collide again
+		wait := uow.backoff << uint(attempt)
+		if uow.backoff > 0 {
+			wait += time.Duration(rand.Int63n(int64(uow.backoff)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// transaction runs fn in new transaction
+func (uow *unitOfWork) transaction(ctx context.Context, fn func(ctx context.Context, tx Store) error) (err error) {
+	begin := uow.store.BeginTx(ctx, &sql.TxOptions{})
+	if begin.Error != nil {
+		return begin.Error
+	}
+
+	// statements of transaction are bound to ctx it was started with, begin keeps
+	// settings of application store so it is used as is
+	tx := &transaction{store: &boundStore{gormDB: begin}}
+
+	defer func() {
+		if p := recover(); p != nil {
+			begin.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, transactionKey{}, tx), tx.store); err != nil {
+		if rerr := begin.Rollback().Error; rerr != nil && rerr != sql.ErrTxDone {
+			return fmt.Errorf("%v, rollback failed: %v", err, rerr)
+		}
+		return err
+	}
+
+	return begin.Commit().Error
+}
+
+// savepoint runs fn in savepoint of transaction carried by ctx
+//
+// failed fn rolls back only its own changes, enclosing transaction can continue
+func (uow *unitOfWork) savepoint(ctx context.Context, tx *transaction, fn func(ctx context.Context, tx Store) error) (err error) {
+	tx.savepoints++
+	name := fmt.Sprintf("sp_%d", tx.savepoints)
+
+	bound := tx.store
+	if err := bound.Exec("SAVEPOINT " + name).Error; err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			bound.Exec("ROLLBACK TO SAVEPOINT " + name)
+			panic(p)
+		}
+	}()
+
+	if err = fn(ctx, bound); err != nil {
+		// deadlock rolls back whole transaction, there is no savepoint to return to
+		if !IsDeadlock(err) {
+			if rerr := bound.Exec("ROLLBACK TO SAVEPOINT " + name).Error; rerr != nil {
+				return fmt.Errorf("%v, rollback to savepoint failed: %v", err, rerr)
+			}
+		}
+		return err
+	}
+
+	return bound.Exec("RELEASE SAVEPOINT " + name).Error
+}
+
+// IsDeadlock returns true if err is caused by transaction chosen as deadlock victim
+func IsDeadlock(err error) bool {
+	var merr *mysql.MySQLError
+	if errors.As(err, &merr) {
+		return merr.Number == mysqlDeadlock
+	}
+
+	var perr *pq.Error
+	return errors.As(err, &perr) && perr.Code == postgresDeadlock
+}
@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const updateQuery = "UPDATE users SET is_active = ? WHERE id = ?"
+
+// newUnitOfWorkMock creates unit of work running transactions on sqlmock database
+func newUnitOfWorkMock(t *testing.T) (*unitOfWork, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gdb, err := gorm.Open("mysql", sqlDB)
+	require.NoError(t, err)
+
+	return &unitOfWork{store: gdb, maxRetries: 2, backoff: time.Millisecond}, mock
+}
+
+// update executes statement the way repositories do, binding application store to ctx
+func update(ctx context.Context, uow *unitOfWork) error {
+	return WithContext(ctx, uow.store).Exec(updateQuery, true, 1).Error
+}
+
+func TestWithTransaction_Commit(t *testing.T) {
+	uow, mock := newUnitOfWorkMock(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(updateQuery)).WithArgs(true, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := uow.WithTransaction(context.Background(), func(ctx context.Context, tx Store) error {
+		return update(ctx, uow)
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTransaction_Rollback(t *testing.T) {
+	uow, mock := newUnitOfWorkMock(t)
+	errFailed := errors.New("failed")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(updateQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	err := uow.WithTransaction(context.Background(), func(ctx context.Context, tx Store) error {
+		if err := tx.Exec(updateQuery, true, 1).Error; err != nil {
+			return err
+		}
+		return errFailed
+	})
+
+	assert.Equal(t, errFailed, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTransaction_Panic(t *testing.T) {
+	uow, mock := newUnitOfWorkMock(t)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	assert.PanicsWithValue(t, "boom", func() {
+		uow.WithTransaction(context.Background(), func(ctx context.Context, tx Store) error {
+			panic("boom")
+		})
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTransaction_Savepoint(t *testing.T) {
+	uow, mock := newUnitOfWorkMock(t)
+	errFailed := errors.New("failed")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(updateQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT sp_2").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_2").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := uow.WithTransaction(context.Background(), func(ctx context.Context, tx Store) error {
+		err := uow.WithTransaction(ctx, func(ctx context.Context, tx Store) error {
+			return update(ctx, uow)
+		})
+		require.NoError(t, err)
+
+		// failed nested work is rolled back, enclosing transaction continues
+		err = uow.WithTransaction(ctx, func(ctx context.Context, tx Store) error {
+			return errFailed
+		})
+		assert.Equal(t, errFailed, err)
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTransaction_DeadlockRetry(t *testing.T) {
+	uow, mock := newUnitOfWorkMock(t)
+	deadlock := &mysql.MySQLError{Number: mysqlDeadlock, Message: "Deadlock found when trying to get lock"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(updateQuery)).WillReturnError(deadlock)
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(updateQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	attempts := 0
+	err := uow.WithTransaction(context.Background(), func(ctx context.Context, tx Store) error {
+		attempts++
+		return update(ctx, uow)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTransaction_DeadlockRetriesExhausted(t *testing.T) {
+	uow, mock := newUnitOfWorkMock(t)
+	deadlock := &mysql.MySQLError{Number: mysqlDeadlock, Message: "Deadlock found when trying to get lock"}
+
+	for i := 0; i <= uow.maxRetries; i++ {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(updateQuery)).WillReturnError(deadlock)
+		mock.ExpectRollback()
+	}
+
+	attempts := 0
+	err := uow.WithTransaction(context.Background(), func(ctx context.Context, tx Store) error {
+		attempts++
+		return update(ctx, uow)
+	})
+
+	assert.True(t, IsDeadlock(err))
+	assert.Equal(t, 3, attempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsDeadlock(t *testing.T) {
+	assert.True(t, IsDeadlock(&mysql.MySQLError{Number: mysqlDeadlock}))
+	assert.True(t, IsDeadlock(fmt.Errorf("update: %w", &pq.Error{Code: postgresDeadlock})))
+	assert.False(t, IsDeadlock(&mysql.MySQLError{Number: 1062}))
+	assert.False(t, IsDeadlock(&pq.Error{Code: "23505"}))
+	assert.False(t, IsDeadlock(errors.New("failed")))
+}
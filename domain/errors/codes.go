@@ -0,0 +1,79 @@
+package errors
+
+// Error codes catalog
+//
+// codes are part of API contract, existing codes must never change their meaning
+const (
+	// CodeTosNotAccepted is returned when authenticated user has not accepted current terms of service
+	CodeTosNotAccepted = 1001
+	// CodeAccountInactive is returned when user account is not active
+	CodeAccountInactive = 1002
+	// CodeAccountSuspended is returned when user account is suspended
+	CodeAccountSuspended = 1003
+
+	// CodeInvalidCredentials is returned when email and password do not match
+	CodeInvalidCredentials = 1101
+	// CodeInvalidRefreshToken is returned when refresh token is invalid, expired or revoked
+	CodeInvalidRefreshToken = 1102
+	// CodeInvalidUserToken is returned when emailed token is invalid or expired
+	CodeInvalidUserToken = 1103
+	// CodeInvalidOneTimeCode is returned when one time code is invalid or expired
+	CodeInvalidOneTimeCode = 1104
+	// CodeOneTimeCodeAttemptsExceeded is returned when one time code was guessed too many times
+	CodeOneTimeCodeAttemptsExceeded = 1105
+	// CodeThrottled is returned when message was requested again too soon
+	CodeThrottled = 1106
+
+	// CodeEmailTaken is returned when email belongs to another account
+	CodeEmailTaken = 1201
+	// CodeEmailAlreadyVerified is returned when email is already verified
+	CodeEmailAlreadyVerified = 1202
+	// CodePhoneNumberMissing is returned when phone verification is requested without phone number
+	CodePhoneNumberMissing = 1203
+	// CodePhoneAlreadyVerified is returned when phone number is already verified
+	CodePhoneAlreadyVerified = 1204
+	// CodeInvalidPhoneNumber is returned when phone number cannot be normalized
+	CodeInvalidPhoneNumber = 1205
+
+	// CodeTwoFactorNotEnrolled is returned when two-factor authentication is not enrolled
+	CodeTwoFactorNotEnrolled = 1301
+	// CodeTwoFactorAlreadyEnabled is returned when two-factor authentication is already enabled
+	CodeTwoFactorAlreadyEnabled = 1302
+	// CodeInvalidTwoFactorCode is returned when two-factor or recovery code is not valid
+	CodeInvalidTwoFactorCode = 1303
+	// CodeInvalidTwoFactorLogin is returned when two-factor login challenge or code is not valid
+	CodeInvalidTwoFactorLogin = 1304
+
+	// CodeInvalidStatusTransition is returned when account status cannot change as requested
+	CodeInvalidStatusTransition = 1401
+	// CodeOwnStatusChange is returned when user tries to change status of own account
+	CodeOwnStatusChange = 1402
+	// CodeInvalidSuspensionExpiry is returned when suspension expiry is not in the future
+	CodeInvalidSuspensionExpiry = 1403
+	// CodeUnchangedStatus is returned when status transition does not change status
+	CodeUnchangedStatus = 1404
+
+	// CodeInvitationPending is returned when email already has pending invitation
+	CodeInvitationPending = 1501
+	// CodeInvalidInvitation is returned when invitation token is invalid or expired
+	CodeInvalidInvitation = 1502
+	// CodeInvitationNotPending is returned when invitation was already accepted or revoked
+	CodeInvitationNotPending = 1503
+
+	// CodeNoTosVersion is returned when terms of service have not been published
+	CodeNoTosVersion = 1601
+	// CodeTosVersionOutdated is returned when accepted terms of service version is not current
+	CodeTosVersionOutdated = 1602
+
+	// CodeEmptySearchQuery is returned when search query has no searchable content
+	CodeEmptySearchQuery = 1701
+
+	// CodeRecordNotFound is returned when requested record does not exist
+	CodeRecordNotFound = 9001
+	// CodeDuplicateRecord is returned when record violates unique constraint
+	CodeDuplicateRecord = 9002
+	// CodeRecordReferenced is returned when record cannot be deleted or changed because other records reference it
+	CodeRecordReferenced = 9003
+	// CodeInvalidReference is returned when record references record which does not exist
+	CodeInvalidReference = 9004
+)
@@ -0,0 +1,142 @@
+// Package errors defines typed domain errors
+//
+// every error has kind, which decides HTTP status it is rendered with,
+// and stable machine readable code from the catalog rendered as ResponseError.Code
+package errors
+
+import "errors"
+
+// Kind classifies domain error
+type Kind int
+
+const (
+	// KindNotFound is returned when requested record does not exist
+	KindNotFound Kind = iota + 1
+
+	// KindConflict is returned when operation conflicts with current state of record
+	KindConflict
+
+	// KindValidation is returned when request input is not valid
+	KindValidation
+
+	// KindUnauthorized is returned when request credentials are missing or not valid
+	KindUnauthorized
+
+	// KindForbidden is returned when authenticated user is not allowed to perform operation
+	KindForbidden
+
+	// KindRateLimited is returned when operation was attempted too many times
+	KindRateLimited
+)
+
+// String returns kind name
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not_found"
+	case KindConflict:
+		return "conflict"
+	case KindValidation:
+		return "validation"
+	case KindUnauthorized:
+		return "unauthorized"
+	case KindForbidden:
+		return "forbidden"
+	case KindRateLimited:
+		return "rate_limited"
+	}
+	return "unknown"
+}
+
+// Error is typed domain error
+type Error struct {
+	// Kind classifies error
+	Kind Kind
+	// Code is stable machine readable error code
+	Code int
+	// Message is human readable error description
+	Message string
+	// Err is underlying error, e.g. database error error was translated from
+	Err error
+}
+
+// New creates domain error of given kind
+func New(kind Kind, code int, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// NotFound creates domain error of KindNotFound
+func NotFound(code int, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+// Conflict creates domain error of KindConflict
+func Conflict(code int, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+// Validation creates domain error of KindValidation
+func Validation(code int, message string) *Error {
+	return New(KindValidation, code, message)
+}
+
+// Unauthorized creates domain error of KindUnauthorized
+func Unauthorized(code int, message string) *Error {
+	return New(KindUnauthorized, code, message)
+}
+
+// Forbidden creates domain error of KindForbidden
+func Forbidden(code int, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+// RateLimited creates domain error of KindRateLimited
+func RateLimited(code int, message string) *Error {
+	return New(KindRateLimited, code, message)
+}
+
+// Error implements error interface
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ErrorCode returns machine readable error code
+func (e *Error) ErrorCode() int {
+	return e.Code
+}
+
+// Unwrap returns underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is domain error with the same code,
+// so errors created with Wrap match their catalog sentinel
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Wrap returns copy of domain error e with err as underlying error
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+// As returns first domain error in err chain
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// KindOf returns kind of first domain error in err chain, 0 when err is not domain error
+func KindOf(err error) Kind {
+	if e, ok := As(err); ok {
+		return e.Kind
+	}
+	return 0
+}
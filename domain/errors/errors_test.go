@@ -0,0 +1,52 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_Wrap(t *testing.T) {
+	sentinel := Conflict(CodeDuplicateRecord, "record already exists")
+	cause := errors.New("Error 1062: Duplicate entry")
+
+	err := sentinel.Wrap(cause)
+
+	assert.Equal(t, "record already exists", err.Error())
+	assert.Equal(t, CodeDuplicateRecord, err.ErrorCode())
+	assert.True(t, errors.Is(err, sentinel), "expected wrapped error to match its sentinel")
+	assert.True(t, errors.Is(err, cause), "expected wrapped error to keep its cause")
+	assert.Nil(t, sentinel.Err, "expected sentinel not to be modified")
+}
+
+func TestError_IsComparesCodes(t *testing.T) {
+	err := NotFound(CodeNoTosVersion, "terms of service have not been published")
+
+	assert.False(t, errors.Is(err, NotFound(CodeRecordNotFound, "record not found")))
+	assert.False(t, errors.Is(err, errors.New("terms of service have not been published")))
+}
+
+func TestKindOf(t *testing.T) {
+	err := RateLimited(CodeThrottled, "too many requests")
+
+	assert.Equal(t, KindRateLimited, KindOf(err))
+	assert.Equal(t, KindRateLimited, KindOf(pkgerrors.Wrap(err, "sending message")))
+	assert.Equal(t, KindRateLimited, KindOf(fmt.Errorf("sending message: %w", err)))
+	assert.Equal(t, Kind(0), KindOf(errors.New("plain error")))
+	assert.Equal(t, Kind(0), KindOf(nil))
+}
+
+func TestAs(t *testing.T) {
+	err := Forbidden(CodeAccountSuspended, "account is suspended")
+
+	derr, ok := As(pkgerrors.WithStack(err))
+
+	assert.True(t, ok)
+	assert.Equal(t, err, derr)
+
+	_, ok = As(errors.New("plain error"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// AuthTokens holds tokens issued to authenticated user
+//
+// it is rendered through views.AuthTokens, user is never rendered raw
+type AuthTokens struct {
+	TokenType             string    `json:"token_type"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+	User                  *User     `json:"-"`
+}
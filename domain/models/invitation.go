@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+const (
+	// InvitationStatusPending marks invitations waiting to be accepted
+	InvitationStatusPending = "pending"
+
+	// InvitationStatusAccepted marks invitations used to create an account
+	InvitationStatusAccepted = "accepted"
+
+	// InvitationStatusRevoked marks invitations cancelled by inviter
+	InvitationStatusRevoked = "revoked"
+)
+
+// Invitation model
+type Invitation struct {
+	ID             uint64     `json:"id"`
+	InviterUserID  uint64     `json:"inviter_user_id"`
+	Email          string     `json:"email"`
+	TokenHash      string     `json:"-" sensitive:"true"`
+	Status         string     `json:"status"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcceptedUserID *uint64    `json:"accepted_user_id"`
+	AcceptedAt     *time.Time `json:"accepted_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// IsAcceptable reports whether invitation is still pending and not expired
+func (i *Invitation) IsAcceptable(now time.Time) bool {
+	return i.Status == InvitationStatusPending && now.Before(i.ExpiresAt)
+}
+
+// Invitee view model represents node in invitation tree
+type Invitee struct {
+	User     *User      `json:"user"`
+	Invitees []*Invitee `json:"invitees"`
+}
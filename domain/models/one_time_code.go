@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+const (
+	// OneTimeCodePurposePhoneVerification marks codes sent to verify user phone number
+	OneTimeCodePurposePhoneVerification = "phone_verification"
+)
+
+// OneTimeCode model holds short numeric codes sent to users
+type OneTimeCode struct {
+	ID          uint64     `json:"id"`
+	UserID      uint64     `json:"user_id"`
+	Purpose     string     `json:"purpose"`
+	Destination string     `json:"destination"`
+	CodeHash    string     `json:"-" sensitive:"true"`
+	Attempts    int        `json:"attempts"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	UsedAt      *time.Time `json:"used_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// IsUsable reports whether code was not used yet, is not expired and has attempts left
+func (c *OneTimeCode) IsUsable(now time.Time, maxAttempts int) bool {
+	return c.UsedAt == nil && now.Before(c.ExpiresAt) && c.Attempts < maxAttempts
+}
@@ -3,6 +3,8 @@ package models
 import "github.com/go-flow/template-api/pkg/paging"
 
 // PaginatedModel model
+//
+// Paginator describes page or cursor pagination, depending on requested mode
 type PaginatedModel struct {
 	Results   interface{}       `json:"results"`
 	Paginator *paging.Paginator `json:"paginator"`
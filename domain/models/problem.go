@@ -0,0 +1,21 @@
+package models
+
+// Problem is RFC 7807 problem details view model, rendered as `application/problem+json`
+type Problem struct {
+	// Type is URI reference identifying problem type
+	Type string `json:"type"`
+	// Title is short summary of problem type
+	Title string `json:"title"`
+	// Status is HTTP status code
+	Status int `json:"status"`
+	// Detail is explanation specific to this occurrence of problem
+	Detail string `json:"detail,omitempty"`
+	// Instance identifies this occurrence of problem, it holds request ID
+	Instance string `json:"instance,omitempty"`
+	// Code is machine readable error code extension
+	Code int `json:"code,omitempty"`
+	// Errors is validation errors extension, mapping invalid fields to failed rules
+	Errors map[string]string `json:"errors,omitempty"`
+	// Messages is localized validation errors extension, mapping invalid fields to human readable messages
+	Messages map[string]string `json:"messages,omitempty"`
+}
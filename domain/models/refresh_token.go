@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// RefreshToken model
+type RefreshToken struct {
+	ID           uint64     `json:"id"`
+	UserID       uint64     `json:"user_id"`
+	TokenHash    string     `json:"-" sensitive:"true"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	ReplacedByID *uint64    `json:"replaced_by_id"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// IsActive reports whether refresh token can still be exchanged for new tokens
+func (t *RefreshToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
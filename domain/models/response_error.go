@@ -7,4 +7,5 @@ type ResponseError struct {
 	Message    string            `json:"message"`
 	Stack      string            `json:"stack"`
 	Validation map[string]string `json:"validation"`
+	Messages   map[string]string `json:"messages"`
 }
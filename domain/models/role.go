@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// names of roles created by migrations
+const (
+	// RoleAdmin is granted all permissions
+	RoleAdmin = "admin"
+
+	// RoleUser is assigned to every new user
+	RoleUser = "user"
+)
+
+// Role model
+type Role struct {
+	ID          uint64    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Permission model
+type Permission struct {
+	ID          uint64    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// UserRole model links users with their roles
+type UserRole struct {
+	UserID    uint64    `json:"user_id" gorm:"primary_key;auto_increment:false"`
+	RoleID    uint64    `json:"role_id" gorm:"primary_key;auto_increment:false"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// TosVersion model holds published terms of service version
+type TosVersion struct {
+	ID          uint64    `json:"id"`
+	Version     string    `json:"version"`
+	Summary     string    `json:"summary"`
+	URL         string    `json:"url"`
+	PublishedAt time.Time `json:"published_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// UserTosAcceptance model records user accepting terms of service version
+type UserTosAcceptance struct {
+	ID           uint64      `json:"id"`
+	UserID       uint64      `json:"user_id"`
+	TosVersionID uint64      `json:"tos_version_id"`
+	TosVersion   *TosVersion `json:"tos_version,omitempty"`
+	IPAddress    string      `json:"ip_address"`
+	UserAgent    string      `json:"user_agent"`
+	AcceptedAt   time.Time   `json:"accepted_at"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// TwoFactorCredential model holds user TOTP secret
+//
+// secret is stored encrypted, credential is enabled once user confirms enrollment
+type TwoFactorCredential struct {
+	UserID          uint64     `json:"user_id" gorm:"primary_key;auto_increment:false"`
+	SecretEncrypted string     `json:"-" sensitive:"true"`
+	EnabledAt       *time.Time `json:"enabled_at"`
+	LastUsedStep    int64      `json:"-"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// IsEnabled reports whether user confirmed two-factor enrollment
+func (c *TwoFactorCredential) IsEnabled() bool {
+	return c.EnabledAt != nil
+}
+
+// TwoFactorRecoveryCode model holds hashed single-use recovery code
+type TwoFactorRecoveryCode struct {
+	ID        uint64     `json:"id"`
+	UserID    uint64     `json:"user_id"`
+	CodeHash  string     `json:"-" sensitive:"true"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TwoFactorEnrollment view model returned when user starts two-factor enrollment
+type TwoFactorEnrollment struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+// TwoFactorRecoveryCodes view model returned once after two-factor enrollment is confirmed
+type TwoFactorRecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorChallenge view model returned by login when second authentication step is required
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool      `json:"two_factor_required"`
+	ChallengeToken    string    `json:"challenge_token"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
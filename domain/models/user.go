@@ -1,14 +1,50 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/go-flow/template-api/pkg/paging"
+)
+
+// UserFields maps user API fields which can be requested in sparse fieldset to columns
+var UserFields = paging.FieldWhitelist{
+	"id":                 "id",
+	"first_name":         "first_name",
+	"last_name":          "last_name",
+	"profile_image":      "profile_image",
+	"email":              "email",
+	"is_email_verified":  "is_email_verified",
+	"bio":                "bio",
+	"phone_number":       "phone_number",
+	"is_phone_verified":  "is_phone_verified",
+	"country":            "country",
+	"state":              "state",
+	"area":               "area",
+	"city":               "city",
+	"address":            "address",
+	"post_code":          "post_code",
+	"birth_date":         "birth_date",
+	"tos_accepted":       "tos_accepted",
+	"invited_by_user_id": "invited_by_user_id",
+	"is_active":          "is_active",
+	"suspended_at":       "suspended_at",
+	"suspended_until":    "suspended_until",
+	"suspended_reason":   "suspended_reason",
+	"created_at":         "created_at",
+	"updated_at":         "updated_at",
+	"deleted_at":         "deleted_at",
+}
 
 // User model
+//
+// fields tagged `sensitive` must never be rendered in responses
 type User struct {
 	ID              uint64     `json:"id"`
 	FirstName       string     `json:"first_name"`
 	LastName        string     `json:"last_name"`
 	ProfileImage    string     `json:"profile_image"`
 	Email           string     `json:"email" binding:"required,email"`
+	PasswordHash    string     `json:"-" sensitive:"true"`
 	IsEmailVerified bool       `json:"is_email_verified"`
 	Bio             string     `json:"bio"`
 	PhoneNumber     string     `json:"phone_number"`
@@ -23,7 +59,31 @@ type User struct {
 	TosAccepted     bool       `json:"tos_accepted"`
 	InvitedByUserID *uint64    `json:"invited_by_user_id"`
 	IsActive        bool       `json:"is_active"`
+	SuspendedAt     *time.Time `json:"suspended_at"`
+	SuspendedUntil  *time.Time `json:"suspended_until"`
+	SuspendedReason string     `json:"suspended_reason"`
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
 	DeletedAt       *time.Time `json:"deleted_at"`
 }
+
+// IsSuspended reports whether user is suspended at given time
+//
+// suspension without expiry lasts until user is reactivated
+func (u *User) IsSuspended(now time.Time) bool {
+	if u.SuspendedAt == nil {
+		return false
+	}
+	return u.SuspendedUntil == nil || now.Before(*u.SuspendedUntil)
+}
+
+// Status returns user account status at given time
+func (u *User) Status(now time.Time) string {
+	if u.IsSuspended(now) {
+		return UserStatusSuspended
+	}
+	if u.IsActive {
+		return UserStatusActive
+	}
+	return UserStatusInactive
+}
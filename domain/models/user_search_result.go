@@ -0,0 +1,9 @@
+package models
+
+// UserSearchResult model describes user matching search query
+type UserSearchResult struct {
+	User  *User   `json:"user"`
+	Score float64 `json:"score"`
+	// Highlights are snippets of matching fields with matched terms wrapped in <mark> tag
+	Highlights map[string]string `json:"highlights"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+const (
+	// UserStatusInactive is status of account which was never activated or was deactivated
+	UserStatusInactive = "inactive"
+
+	// UserStatusActive is status of account which can authenticate
+	UserStatusActive = "active"
+
+	// UserStatusSuspended is status of account suspended until reactivation or suspension expiry
+	UserStatusSuspended = "suspended"
+)
+
+// UserStatusTransition model records change of user account status
+type UserStatusTransition struct {
+	ID          uint64     `json:"id"`
+	UserID      uint64     `json:"user_id"`
+	ActorUserID *uint64    `json:"actor_user_id"`
+	FromStatus  string     `json:"from_status"`
+	ToStatus    string     `json:"to_status"`
+	Reason      string     `json:"reason"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
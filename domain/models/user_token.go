@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+const (
+	// UserTokenPurposeEmailVerification marks tokens sent to verify user email
+	UserTokenPurposeEmailVerification = "email_verification"
+
+	// UserTokenPurposePasswordReset marks tokens sent to reset user password
+	UserTokenPurposePasswordReset = "password_reset"
+
+	// UserTokenPurposeTwoFactorLogin marks tokens issued between password and two-factor login steps
+	UserTokenPurposeTwoFactorLogin = "two_factor_login"
+)
+
+// UserToken model holds single-use, expiring tokens sent to users
+type UserToken struct {
+	ID        uint64     `json:"id"`
+	UserID    uint64     `json:"user_id"`
+	Purpose   string     `json:"purpose"`
+	TokenHash string     `json:"-" sensitive:"true"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// IsUsable reports whether token was not used yet and is not expired
+func (t *UserToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -2,14 +2,57 @@ package requests
 
 // AccountRegister request model
 type AccountRegister struct {
-	FirstName string
-	LastName  string
-	Email     string
-	Password  string
+	FirstName string `json:"first_name" binding:"required,max=255"`
+	LastName  string `json:"last_name" binding:"required,max=255"`
+	Email     string `json:"email" binding:"required,email,max=120"`
+	Password  string `json:"password" binding:"required,min=8,max=72"`
 }
 
 // AccountLogin request model
 type AccountLogin struct {
-	Email    string
-	Password string
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// AccountLoginTwoFactor request model
+type AccountLoginTwoFactor struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// AccountTwoFactorCode request model
+type AccountTwoFactorCode struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// AccountTokenRefresh request model
+type AccountTokenRefresh struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// AccountLogout request model
+type AccountLogout struct {
+	RefreshToken string `json:"refresh_token" binding:"required_without=All"`
+	All          bool   `json:"all"`
+}
+
+// AccountEmailVerify request model
+type AccountEmailVerify struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// AccountPasswordForgot request model
+type AccountPasswordForgot struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// AccountPasswordReset request model
+type AccountPasswordReset struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+// AccountPhoneVerify request model
+type AccountPhoneVerify struct {
+	Code string `json:"code" binding:"required,numeric"`
 }
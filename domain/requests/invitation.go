@@ -0,0 +1,14 @@
+package requests
+
+// InvitationCreate request model
+type InvitationCreate struct {
+	Email string `json:"email" binding:"required,email,max=120"`
+}
+
+// InvitationAccept request model
+type InvitationAccept struct {
+	Token     string `json:"token" binding:"required"`
+	FirstName string `json:"first_name" binding:"required,max=255"`
+	LastName  string `json:"last_name" binding:"required,max=255"`
+	Password  string `json:"password" binding:"required,min=8,max=72"`
+}
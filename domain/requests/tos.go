@@ -0,0 +1,16 @@
+package requests
+
+import "time"
+
+// TosPublish request model
+type TosPublish struct {
+	Version     string     `json:"version" binding:"required,max=50"`
+	Summary     string     `json:"summary" binding:"max=1000"`
+	URL         string     `json:"url" binding:"omitempty,url,max=512"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// TosAccept request model
+type TosAccept struct {
+	TosVersionID uint64 `json:"tos_version_id" binding:"required"`
+}
@@ -0,0 +1,79 @@
+package requests
+
+import "time"
+
+// UserCreate request model
+type UserCreate struct {
+	Email        string     `json:"email" binding:"required,email,max=120"`
+	FirstName    *string    `json:"first_name" binding:"omitempty,max=255"`
+	LastName     *string    `json:"last_name" binding:"omitempty,max=255"`
+	ProfileImage *string    `json:"profile_image" binding:"omitempty,max=255"`
+	BirthDate    *time.Time `json:"birth_date"`
+	Bio          *string    `json:"bio"`
+	PhoneNumber  *string    `json:"phone_number" binding:"omitempty,max=20"`
+	Country      *string    `json:"country" binding:"omitempty,max=255"`
+	State        *string    `json:"state" binding:"omitempty,max=255"`
+	Area         *string    `json:"area" binding:"omitempty,max=255"`
+	City         *string    `json:"city" binding:"omitempty,max=255"`
+	Address      *string    `json:"address" binding:"omitempty,max=255"`
+	PostCode     *string    `json:"post_code" binding:"omitempty,max=50"`
+}
+
+// UserUpdate request model
+//
+// fields which are not present in request body are left unchanged
+type UserUpdate struct {
+	FirstName    *string    `json:"first_name" binding:"omitempty,max=255"`
+	LastName     *string    `json:"last_name" binding:"omitempty,max=255"`
+	ProfileImage *string    `json:"profile_image" binding:"omitempty,max=255"`
+	BirthDate    *time.Time `json:"birth_date"`
+	Bio          *string    `json:"bio"`
+	PhoneNumber  *string    `json:"phone_number" binding:"omitempty,max=20"`
+	Country      *string    `json:"country" binding:"omitempty,max=255"`
+	State        *string    `json:"state" binding:"omitempty,max=255"`
+	Area         *string    `json:"area" binding:"omitempty,max=255"`
+	City         *string    `json:"city" binding:"omitempty,max=255"`
+	Address      *string    `json:"address" binding:"omitempty,max=255"`
+	PostCode     *string    `json:"post_code" binding:"omitempty,max=50"`
+}
+
+// Replace sets all fields missing from request body to their empty values
+//
+// it is used by full resource updates (PUT) where omitted fields are cleared,
+// empty birth date is zero time which clears stored birth date
+func (r *UserUpdate) Replace() {
+	empty := func(s **string) {
+		if *s == nil {
+			*s = new(string)
+		}
+	}
+
+	empty(&r.FirstName)
+	empty(&r.LastName)
+	empty(&r.ProfileImage)
+	empty(&r.Bio)
+	empty(&r.PhoneNumber)
+	empty(&r.Country)
+	empty(&r.State)
+	empty(&r.Area)
+	empty(&r.City)
+	empty(&r.Address)
+	empty(&r.PostCode)
+
+	if r.BirthDate == nil {
+		r.BirthDate = new(time.Time)
+	}
+}
+
+// UserDeactivate request model
+type UserDeactivate struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// UserSuspend request model
+//
+// suspension without until lasts until user is reactivated
+type UserSuspend struct {
+	Reason string     `json:"reason" binding:"required,max=500"`
+	Until  *time.Time `json:"until"`
+}
@@ -0,0 +1,32 @@
+package requests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserUpdate_Replace(t *testing.T) {
+	req := new(UserUpdate)
+	require.NoError(t, json.Unmarshal([]byte(`{"first_name": "Sedin"}`), req))
+
+	req.Replace()
+
+	assert.Equal(t, "Sedin", *req.FirstName)
+	assert.Equal(t, "", *req.LastName)
+	assert.Equal(t, "", *req.PostCode)
+	require.NotNil(t, req.BirthDate, "expected omitted birth date to be cleared")
+	assert.True(t, req.BirthDate.IsZero())
+}
+
+func TestUserUpdate_ReplaceKeepsBirthDate(t *testing.T) {
+	req := new(UserUpdate)
+	require.NoError(t, json.Unmarshal([]byte(`{"birth_date": "1990-05-17T00:00:00Z"}`), req))
+
+	req.Replace()
+
+	require.NotNil(t, req.BirthDate)
+	assert.Equal(t, 1990, req.BirthDate.Year())
+}
@@ -0,0 +1,38 @@
+package views
+
+import (
+	"time"
+
+	"github.com/go-flow/template-api/domain/models"
+)
+
+// AuthTokens is response view of tokens issued to authenticated user
+type AuthTokens struct {
+	TokenType             string    `json:"token_type"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+	User                  *User     `json:"user"`
+}
+
+// NewAuthTokens maps issued tokens to view, user is rendered to itself
+func NewAuthTokens(tokens *models.AuthTokens) *AuthTokens {
+	if tokens == nil {
+		return nil
+	}
+
+	view := &AuthTokens{
+		TokenType:             tokens.TokenType,
+		AccessToken:           tokens.AccessToken,
+		AccessTokenExpiresAt:  tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	}
+
+	if tokens.User != nil {
+		view.User = NewUser(tokens.User, Self(tokens.User.ID))
+	}
+
+	return view
+}
@@ -0,0 +1,159 @@
+package views
+
+import (
+	"time"
+
+	"github.com/go-flow/template-api/domain/models"
+)
+
+// User is response view of user record
+//
+// private fields are rendered only to user itself and admins,
+// and internal account state only to admins
+type User struct {
+	ID           uint64    `json:"id"`
+	FirstName    string    `json:"first_name"`
+	LastName     string    `json:"last_name"`
+	ProfileImage string    `json:"profile_image"`
+	Bio          string    `json:"bio"`
+	Country      string    `json:"country"`
+	City         string    `json:"city"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	*UserPrivate
+	*UserInternal
+}
+
+// UserPrivate holds user fields visible to user itself and admins
+type UserPrivate struct {
+	Email           string     `json:"email"`
+	IsEmailVerified bool       `json:"is_email_verified"`
+	PhoneNumber     string     `json:"phone_number"`
+	IsPhoneVerified bool       `json:"is_phone_verified"`
+	State           string     `json:"state"`
+	Area            string     `json:"area"`
+	Address         string     `json:"address"`
+	PostCode        string     `json:"post_code"`
+	BirthDate       *time.Time `json:"birth_date"`
+	TosAccepted     bool       `json:"tos_accepted"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// UserInternal holds internal account state visible only to admins
+type UserInternal struct {
+	Status          string     `json:"status"`
+	IsActive        bool       `json:"is_active"`
+	SuspendedAt     *time.Time `json:"suspended_at"`
+	SuspendedUntil  *time.Time `json:"suspended_until"`
+	SuspendedReason string     `json:"suspended_reason"`
+	InvitedByUserID *uint64    `json:"invited_by_user_id"`
+	DeletedAt       *time.Time `json:"deleted_at"`
+}
+
+// NewUser maps user model to view visible to viewer
+func NewUser(user *models.User, viewer Viewer) *User {
+	if user == nil {
+		return nil
+	}
+
+	view := &User{
+		ID:           user.ID,
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		ProfileImage: user.ProfileImage,
+		Bio:          user.Bio,
+		Country:      user.Country,
+		City:         user.City,
+		CreatedAt:    user.CreatedAt,
+	}
+
+	audience := viewer.Audience(user.ID)
+
+	if audience >= AudienceSelf {
+		view.UserPrivate = &UserPrivate{
+			Email:           user.Email,
+			IsEmailVerified: user.IsEmailVerified,
+			PhoneNumber:     user.PhoneNumber,
+			IsPhoneVerified: user.IsPhoneVerified,
+			State:           user.State,
+			Area:            user.Area,
+			Address:         user.Address,
+			PostCode:        user.PostCode,
+			BirthDate:       user.BirthDate,
+			TosAccepted:     user.TosAccepted,
+			UpdatedAt:       user.UpdatedAt,
+		}
+	}
+
+	if audience >= AudienceAdmin {
+		view.UserInternal = &UserInternal{
+			Status:          user.Status(time.Now()),
+			IsActive:        user.IsActive,
+			SuspendedAt:     user.SuspendedAt,
+			SuspendedUntil:  user.SuspendedUntil,
+			SuspendedReason: user.SuspendedReason,
+			InvitedByUserID: user.InvitedByUserID,
+			DeletedAt:       user.DeletedAt,
+		}
+	}
+
+	return view
+}
+
+// NewUsers maps list of user models to views visible to viewer
+func NewUsers(users []*models.User, viewer Viewer) []*User {
+	views := make([]*User, len(users))
+	for i, user := range users {
+		views[i] = NewUser(user, viewer)
+	}
+	return views
+}
+
+// Invitee is response view of node in invitation tree
+type Invitee struct {
+	User     *User      `json:"user"`
+	Invitees []*Invitee `json:"invitees"`
+}
+
+// NewInvitees maps invitation tree to views visible to viewer
+func NewInvitees(invitees []*models.Invitee, viewer Viewer) []*Invitee {
+	views := make([]*Invitee, len(invitees))
+	for i, invitee := range invitees {
+		views[i] = &Invitee{
+			User:     NewUser(invitee.User, viewer),
+			Invitees: NewInvitees(invitee.Invitees, viewer),
+		}
+	}
+	return views
+}
+
+// UserSearchResult is response view of user matching search query
+type UserSearchResult struct {
+	User       *User             `json:"user"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+// NewUserSearchResults maps search results to views visible to viewer
+//
+// highlights of fields which are not visible to viewer are dropped
+func NewUserSearchResults(results []*models.UserSearchResult, viewer Viewer) []*UserSearchResult {
+	views := make([]*UserSearchResult, len(results))
+	for i, result := range results {
+		user := NewUser(result.User, viewer)
+
+		highlights := make(map[string]string)
+		for field, snippet := range result.Highlights {
+			if field != "email" || user.UserPrivate != nil {
+				highlights[field] = snippet
+			}
+		}
+
+		views[i] = &UserSearchResult{
+			User:       user,
+			Score:      result.Score,
+			Highlights: highlights,
+		}
+	}
+	return views
+}
@@ -0,0 +1,160 @@
+package views
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testUser() *models.User {
+	now := time.Now()
+	inviter := uint64(7)
+
+	return &models.User{
+		ID:              2,
+		FirstName:       "Sedin",
+		LastName:        "Dugum",
+		Email:           "sedin@mop.ba",
+		PasswordHash:    "hash",
+		PhoneNumber:     "+38761000000",
+		City:            "Sarajevo",
+		IsActive:        true,
+		InvitedByUserID: &inviter,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// renderJSON returns keys of JSON object rendered for v
+func renderJSON(t *testing.T, v interface{}) map[string]interface{} {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	m := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(data, &m))
+	return m
+}
+
+func TestNewUser_Audiences(t *testing.T) {
+	user := testUser()
+
+	public := renderJSON(t, NewUser(user, Viewer{UserID: 1}))
+	assert.Equal(t, "Sedin", public["first_name"])
+	assert.NotContains(t, public, "email")
+	assert.NotContains(t, public, "phone_number")
+	assert.NotContains(t, public, "status")
+
+	self := renderJSON(t, NewUser(user, Self(2)))
+	assert.Equal(t, "sedin@mop.ba", self["email"])
+	assert.NotContains(t, self, "status")
+	assert.NotContains(t, self, "deleted_at")
+	assert.NotContains(t, self, "invited_by_user_id")
+
+	admin := renderJSON(t, NewUser(user, Viewer{UserID: 1, Admin: true}))
+	assert.Equal(t, "sedin@mop.ba", admin["email"])
+	assert.Equal(t, models.UserStatusActive, admin["status"])
+	assert.Contains(t, admin, "deleted_at")
+	assert.EqualValues(t, 7, admin["invited_by_user_id"])
+}
+
+func TestNewUserSearchResults_HidesPrivateHighlights(t *testing.T) {
+	results := []*models.UserSearchResult{{
+		User:       testUser(),
+		Highlights: map[string]string{"email": "<mark>sedin</mark>@mop.ba", "first_name": "<mark>Sedin</mark>"},
+	}}
+
+	views := NewUserSearchResults(results, Viewer{UserID: 1})
+
+	assert.Equal(t, map[string]string{"first_name": "<mark>Sedin</mark>"}, views[0].Highlights)
+}
+
+// sensitiveValue is set to every sensitive field, it must not appear in any rendered response
+const sensitiveValue = "sensitive-value-must-not-leak"
+
+// withSensitiveFields sets all string fields tagged `sensitive` of model to sensitiveValue
+// and returns names those fields would have as JSON keys or database columns
+func withSensitiveFields(t *testing.T, model interface{}) []string {
+	v := reflect.ValueOf(model).Elem()
+	names := make([]string, 0)
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.Tag.Get("sensitive") != "true" {
+			continue
+		}
+
+		require.Equal(t, reflect.String, field.Type.Kind(), "sensitive field %s has to be string", field.Name)
+		v.Field(i).SetString(sensitiveValue)
+		names = append(names, gorm.ToColumnName(field.Name), field.Name)
+	}
+
+	return names
+}
+
+func assertNoSensitiveFields(t *testing.T, names []string, response interface{}) {
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(data), sensitiveValue)
+	for _, name := range names {
+		assert.NotContains(t, string(data), `"`+name+`"`)
+	}
+}
+
+func TestSensitiveFields_User(t *testing.T) {
+	user := testUser()
+	names := withSensitiveFields(t, user)
+	require.NotEmpty(t, names, "expected user to have sensitive fields")
+
+	viewers := []Viewer{{UserID: 1}, Self(user.ID), {UserID: 1, Admin: true}}
+	for _, viewer := range viewers {
+		assertNoSensitiveFields(t, names, NewUser(user, viewer))
+		assertNoSensitiveFields(t, names, NewUsers([]*models.User{user}, viewer))
+		assertNoSensitiveFields(t, names, NewInvitees([]*models.Invitee{{User: user}}, viewer))
+		assertNoSensitiveFields(t, names, NewUserSearchResults([]*models.UserSearchResult{{User: user}}, viewer))
+	}
+}
+
+func TestSensitiveFields_AuthTokens(t *testing.T) {
+	user := testUser()
+	names := withSensitiveFields(t, user)
+
+	tokens := NewAuthTokens(&models.AuthTokens{TokenType: "Bearer", AccessToken: "access", RefreshToken: "refresh", User: user})
+
+	assertNoSensitiveFields(t, names, tokens)
+
+	rendered := renderJSON(t, tokens)
+	require.Contains(t, rendered, "user")
+	assert.Equal(t, "sedin@mop.ba", rendered["user"].(map[string]interface{})["email"])
+	assert.NotContains(t, rendered["user"], "status")
+	assert.NotContains(t, rendered["user"], "invited_by_user_id")
+
+	// model itself never renders user
+	assertNoSensitiveFields(t, names, &models.AuthTokens{User: user})
+	assert.NotContains(t, renderJSON(t, &models.AuthTokens{User: user}), "user")
+}
+
+func TestSensitiveFields_RenderedModels(t *testing.T) {
+	// models rendered in responses without view
+	rendered := []interface{}{
+		&models.Invitation{},
+		&models.RefreshToken{},
+		&models.UserToken{},
+		&models.OneTimeCode{},
+		&models.TwoFactorCredential{},
+		&models.TwoFactorRecoveryCode{},
+	}
+
+	for _, model := range rendered {
+		names := withSensitiveFields(t, model)
+		require.NotEmpty(t, names, "expected %T to have sensitive fields", model)
+
+		assertNoSensitiveFields(t, names, model)
+	}
+}
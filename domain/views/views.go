@@ -0,0 +1,40 @@
+package views
+
+// Audience describes who resource is rendered for, and so which of its fields are visible
+type Audience int
+
+const (
+	// AudiencePublic sees public profile fields of other users
+	AudiencePublic Audience = iota
+
+	// AudienceSelf sees all fields of own account except internal account state
+	AudienceSelf
+
+	// AudienceAdmin sees all fields, including internal account state
+	AudienceAdmin
+)
+
+// Viewer describes authenticated user response is rendered for
+type Viewer struct {
+	// UserID is id of authenticated user
+	UserID uint64
+
+	// Admin is true when viewer is granted access to private fields of other users
+	Admin bool
+}
+
+// Self returns viewer of own account
+func Self(userID uint64) Viewer {
+	return Viewer{UserID: userID}
+}
+
+// Audience returns audience viewer belongs to for user with given id
+func (v Viewer) Audience(userID uint64) Audience {
+	if v.Admin {
+		return AudienceAdmin
+	}
+	if v.UserID == userID {
+		return AudienceSelf
+	}
+	return AudiencePublic
+}
@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+)
+
+// Init starts application background jobs
+func Init(app *flow.App) {
+	start(app, NewUserPurgeJob(app))
+}
+
+// Job is background task executed periodically
+type Job interface {
+	// Name returns job name used in logs
+	Name() string
+
+	// Interval returns delay between two job runs, job is disabled when it is not positive
+	Interval() time.Duration
+
+	// Run executes job once
+	Run(ctx context.Context) error
+}
+
+// start injects job dependencies and runs it periodically in background
+func start(app *flow.App, job Job) {
+	interval := job.Interval()
+	if interval <= 0 {
+		app.Logger.Infof("job `%s` is disabled", job.Name())
+		return
+	}
+
+	app.InjectDeps(job)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := job.Run(context.Background()); err != nil {
+				app.Logger.Errorf("job `%s` failed: %v", job.Name(), err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/flow/log"
+	"github.com/go-flow/template-api/business"
+	"github.com/go-flow/template-api/config"
+)
+
+// UserPurgeJob permanently deletes users soft deleted longer than configured retention period
+type UserPurgeJob struct {
+	// UserBusiness implementation injected by dependency injection
+	UserBusiness business.UserBusiness
+
+	interval time.Duration
+	logger   log.Logger
+}
+
+// NewUserPurgeJob creates new UserPurgeJob instance
+func NewUserPurgeJob(app *flow.App) *UserPurgeJob {
+	cfg := app.AppConfig.(config.AppConfig)
+
+	return &UserPurgeJob{
+		interval: time.Duration(cfg.UserPurgeInterval) * time.Hour,
+		logger:   app.Logger,
+	}
+}
+
+// Name returns job name used in logs
+func (job *UserPurgeJob) Name() string {
+	return "user-purge"
+}
+
+// Interval returns delay between two job runs
+func (job *UserPurgeJob) Interval() time.Duration {
+	return job.interval
+}
+
+// Run permanently deletes users soft deleted longer than retention period
+func (job *UserPurgeJob) Run(ctx context.Context) error {
+	purged, err := job.UserBusiness.PurgeDeleted(ctx)
+	if err != nil {
+		return err
+	}
+
+	if purged > 0 {
+		job.logger.Infof("purged %d soft deleted users", purged)
+	}
+	return nil
+}
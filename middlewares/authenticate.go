@@ -0,0 +1,82 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-flow/flow"
+	apperrors "github.com/go-flow/template-api/domain/errors"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/pkg/token"
+)
+
+// UserKey is context key holding authenticated user model
+const UserKey = "authUser"
+
+// ErrMissingToken is returned when request does not carry bearer access token
+var ErrMissingToken = errors.New("missing bearer access token")
+
+// Authenticator resolves user model from access token
+type Authenticator interface {
+	Authenticate(ctx context.Context, accessToken string) (*models.User, error)
+}
+
+// Authenticate creates middleware which requires valid bearer access token
+//
+// authenticated user is stored in context under UserKey, requests without
+// valid token are aborted and served by application unauthorized handler,
+// requests of users rejected with forbidden error are aborted with 403 status code
+func Authenticate(auth Authenticator) flow.HandlerFunc {
+	return func(ctx *flow.Context) {
+		accessToken := BearerToken(ctx)
+		if accessToken == "" {
+			abort(ctx, http.StatusUnauthorized, ErrMissingToken)
+			return
+		}
+
+		user, err := auth.Authenticate(ctx.Request.Context(), accessToken)
+		if err == token.ErrInvalidToken || err == token.ErrExpiredToken {
+			abort(ctx, http.StatusUnauthorized, err)
+			return
+		}
+		if apperrors.KindOf(err) == apperrors.KindForbidden {
+			// forbidden errors reject known user, e.g. inactive or suspended account
+			abort(ctx, http.StatusForbidden, err)
+			return
+		}
+		if err != nil {
+			abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.Set(UserKey, user)
+		ctx.Next()
+	}
+}
+
+// CurrentUser returns authenticated user stored in context by Authenticate middleware
+func CurrentUser(ctx *flow.Context) (*models.User, bool) {
+	v, ok := ctx.Get(UserKey)
+	if !ok {
+		return nil, false
+	}
+	user, ok := v.(*models.User)
+	return user, ok
+}
+
+// BearerToken extracts token from `Authorization: Bearer <token>` request header
+func BearerToken(ctx *flow.Context) string {
+	header := ctx.Header("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(header[7:])
+}
+
+// abort stops handlers chain and serves error through application error handlers
+func abort(ctx *flow.Context, code int, err error) {
+	ctx.Abort()
+	ctx.ServeError(code, err)
+}
@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/db"
+)
+
+// RequestContext creates middleware which binds request ID to request context
+// and bounds database work of request with given timeout
+//
+// handlers pass `ctx.Request.Context()` down to repositories, so statements are
+// canceled when client disconnects or timeout passes. Zero timeout disables deadline.
+// Once request writes to database its reads are executed on primary, so it never
+// reads replica which has not replicated its changes yet. Stores are bound to
+// request context once and shared by repositories called during request
+func RequestContext(timeout time.Duration) flow.HandlerFunc {
+	return func(ctx *flow.Context) {
+		c := db.WithRequestID(ctx.Request.Context(), ctx.RequestID())
+		c = db.WithReadYourWrites(c)
+		c = db.WithScope(c)
+
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			c, cancel = context.WithTimeout(c, timeout)
+			defer cancel()
+		}
+
+		ctx.Request = ctx.Request.WithContext(c)
+		ctx.Next()
+	}
+}
@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-flow/flow"
+)
+
+// PermissionChecker checks if user is granted permission
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, userID uint64, permission string) (bool, error)
+}
+
+// RequirePermission creates middleware which allows only users granted given permission
+//
+// it has to be used after Authenticate middleware, requests from users
+// without permission are aborted and served with 403 status code
+func RequirePermission(checker PermissionChecker, permission string) flow.HandlerFunc {
+	return func(ctx *flow.Context) {
+		user, ok := CurrentUser(ctx)
+		if !ok {
+			abort(ctx, http.StatusUnauthorized, ErrMissingToken)
+			return
+		}
+
+		granted, err := checker.HasPermission(ctx.Request.Context(), user.ID, permission)
+		if err != nil {
+			abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		if !granted {
+			abort(ctx, http.StatusForbidden, fmt.Errorf("permission `%s` is required", permission))
+			return
+		}
+
+		ctx.Next()
+	}
+}
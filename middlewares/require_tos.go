@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-flow/flow"
+	apperrors "github.com/go-flow/template-api/domain/errors"
+)
+
+// ErrTosNotAccepted is returned when authenticated user has not accepted current terms of service
+var ErrTosNotAccepted = apperrors.Forbidden(apperrors.CodeTosNotAccepted, "current terms of service have not been accepted")
+
+// TosChecker checks if user accepted current terms of service
+type TosChecker interface {
+	HasAcceptedCurrentTos(ctx context.Context, userID uint64) (bool, error)
+}
+
+// RequireTosAccepted creates middleware which allows only users who accepted current terms of service
+//
+// it has to be used after Authenticate middleware, requests from users who did not accept
+// current version are aborted with 403 status code and CodeTosNotAccepted error code
+func RequireTosAccepted(checker TosChecker) flow.HandlerFunc {
+	return func(ctx *flow.Context) {
+		user, ok := CurrentUser(ctx)
+		if !ok {
+			abort(ctx, http.StatusUnauthorized, ErrMissingToken)
+			return
+		}
+
+		accepted, err := checker.HasAcceptedCurrentTos(ctx.Request.Context(), user.ID)
+		if err != nil {
+			abort(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		if !accepted {
+			abort(ctx, http.StatusForbidden, ErrTosNotAccepted)
+			return
+		}
+
+		ctx.Next()
+	}
+}
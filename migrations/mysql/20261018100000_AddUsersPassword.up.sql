@@ -0,0 +1,2 @@
+ALTER TABLE `users`
+    ADD COLUMN `password_hash` VARCHAR(255) NOT NULL DEFAULT '' AFTER `email`;
@@ -0,0 +1,19 @@
+CREATE TABLE `refresh_tokens`
+(
+    `id`             INT unsigned NOT NULL AUTO_INCREMENT,
+    `user_id`        INT unsigned NOT NULL,
+    `token_hash`     CHAR(64)     NOT NULL,
+    `expires_at`     TIMESTAMP    NULL,
+    `revoked_at`     TIMESTAMP    NULL,
+    `replaced_by_id` INT unsigned NULL,
+    `created_at`     TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`     TIMESTAMP             DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `refresh_tokens_token_hash_idx` (`token_hash` ASC),
+    INDEX `fk_refresh_tokens_user_id_idx` (`user_id` ASC),
+    CONSTRAINT `fk_refresh_tokens_user_id`
+        FOREIGN KEY (`user_id`)
+            REFERENCES `users` (`id`)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE
+) ENGINE = InnoDB;
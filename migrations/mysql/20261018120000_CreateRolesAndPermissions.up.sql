@@ -0,0 +1,77 @@
+CREATE TABLE `roles`
+(
+    `id`          INT unsigned NOT NULL AUTO_INCREMENT,
+    `name`        VARCHAR(100) NOT NULL,
+    `description` VARCHAR(255) NOT NULL DEFAULT '',
+    `created_at`  TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`  TIMESTAMP             DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `roles_name_idx` (`name` ASC)
+) ENGINE = InnoDB;
+
+CREATE TABLE `permissions`
+(
+    `id`          INT unsigned NOT NULL AUTO_INCREMENT,
+    `name`        VARCHAR(100) NOT NULL,
+    `description` VARCHAR(255) NOT NULL DEFAULT '',
+    `created_at`  TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`  TIMESTAMP             DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `permissions_name_idx` (`name` ASC)
+) ENGINE = InnoDB;
+
+CREATE TABLE `role_permissions`
+(
+    `role_id`       INT unsigned NOT NULL,
+    `permission_id` INT unsigned NOT NULL,
+    PRIMARY KEY (`role_id`, `permission_id`),
+    INDEX `fk_role_permissions_permission_id_idx` (`permission_id` ASC),
+    CONSTRAINT `fk_role_permissions_role_id`
+        FOREIGN KEY (`role_id`)
+            REFERENCES `roles` (`id`)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE,
+    CONSTRAINT `fk_role_permissions_permission_id`
+        FOREIGN KEY (`permission_id`)
+            REFERENCES `permissions` (`id`)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE
+) ENGINE = InnoDB;
+
+CREATE TABLE `user_roles`
+(
+    `user_id`    INT unsigned NOT NULL,
+    `role_id`    INT unsigned NOT NULL,
+    `created_at` TIMESTAMP    DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`user_id`, `role_id`),
+    INDEX `fk_user_roles_role_id_idx` (`role_id` ASC),
+    CONSTRAINT `fk_user_roles_user_id`
+        FOREIGN KEY (`user_id`)
+            REFERENCES `users` (`id`)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE,
+    CONSTRAINT `fk_user_roles_role_id`
+        FOREIGN KEY (`role_id`)
+            REFERENCES `roles` (`id`)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE
+) ENGINE = InnoDB;
+
+INSERT INTO `roles` (`name`, `description`)
+VALUES ('admin', 'Full access to all resources'),
+       ('user', 'Default role for registered users');
+
+INSERT INTO `permissions` (`name`, `description`)
+VALUES ('*', 'All permissions'),
+       ('users:read', 'List and read users'),
+       ('users:create', 'Create users'),
+       ('users:update', 'Update users'),
+       ('users:delete', 'Delete users'),
+       ('roles:assign', 'Assign and revoke user roles');
+
+INSERT INTO `role_permissions` (`role_id`, `permission_id`)
+SELECT `roles`.`id`, `permissions`.`id`
+FROM `roles`,
+     `permissions`
+WHERE `roles`.`name` = 'admin'
+  AND `permissions`.`name` = '*';
@@ -0,0 +1,19 @@
+CREATE TABLE `user_tokens`
+(
+    `id`         INT unsigned NOT NULL AUTO_INCREMENT,
+    `user_id`    INT unsigned NOT NULL,
+    `purpose`    VARCHAR(50)  NOT NULL,
+    `token_hash` CHAR(64)     NOT NULL,
+    `expires_at` TIMESTAMP    NULL,
+    `used_at`    TIMESTAMP    NULL,
+    `created_at` TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP             DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `user_tokens_token_hash_idx` (`token_hash` ASC),
+    INDEX `user_tokens_user_id_purpose_idx` (`user_id` ASC, `purpose` ASC),
+    CONSTRAINT `fk_user_tokens_user_id`
+        FOREIGN KEY (`user_id`)
+            REFERENCES `users` (`id`)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE
+) ENGINE = InnoDB;
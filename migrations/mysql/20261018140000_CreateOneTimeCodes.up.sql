@@ -0,0 +1,20 @@
+CREATE TABLE `one_time_codes`
+(
+    `id`          INT unsigned NOT NULL AUTO_INCREMENT,
+    `user_id`     INT unsigned NOT NULL,
+    `purpose`     VARCHAR(50)  NOT NULL,
+    `destination` VARCHAR(255) NOT NULL DEFAULT '',
+    `code_hash`   CHAR(64)     NOT NULL,
+    `attempts`    INT unsigned NOT NULL DEFAULT 0,
+    `expires_at`  TIMESTAMP    NULL,
+    `used_at`     TIMESTAMP    NULL,
+    `created_at`  TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`  TIMESTAMP             DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX `one_time_codes_user_id_purpose_idx` (`user_id` ASC, `purpose` ASC),
+    CONSTRAINT `fk_one_time_codes_user_id`
+        FOREIGN KEY (`user_id`)
+            REFERENCES `users` (`id`)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE
+) ENGINE = InnoDB;
@@ -0,0 +1,40 @@
+CREATE TABLE `tos_versions`
+(
+    `id`           INT unsigned  NOT NULL AUTO_INCREMENT,
+    `version`      VARCHAR(50)   NOT NULL,
+    `summary`      VARCHAR(1000) NOT NULL DEFAULT '',
+    `url`          VARCHAR(512)  NOT NULL DEFAULT '',
+    `published_at` TIMESTAMP     NULL,
+    `created_at`   TIMESTAMP              DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`   TIMESTAMP              DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `tos_versions_version_idx` (`version` ASC),
+    INDEX `tos_versions_published_at_idx` (`published_at` ASC)
+) ENGINE = InnoDB;
+
+CREATE TABLE `user_tos_acceptances`
+(
+    `id`             INT unsigned NOT NULL AUTO_INCREMENT,
+    `user_id`        INT unsigned NOT NULL,
+    `tos_version_id` INT unsigned NOT NULL,
+    `ip_address`     VARCHAR(45)  NOT NULL DEFAULT '',
+    `user_agent`     VARCHAR(512) NOT NULL DEFAULT '',
+    `accepted_at`    TIMESTAMP    NULL,
+    `created_at`     TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `user_tos_acceptances_user_id_tos_version_id_idx` (`user_id` ASC, `tos_version_id` ASC),
+    INDEX `fk_user_tos_acceptances_tos_version_id_idx` (`tos_version_id` ASC),
+    CONSTRAINT `fk_user_tos_acceptances_user_id`
+        FOREIGN KEY (`user_id`)
+            REFERENCES `users` (`id`)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE,
+    CONSTRAINT `fk_user_tos_acceptances_tos_version_id`
+        FOREIGN KEY (`tos_version_id`)
+            REFERENCES `tos_versions` (`id`)
+            ON DELETE RESTRICT
+            ON UPDATE CASCADE
+) ENGINE = InnoDB;
+
+INSERT INTO `permissions` (`name`, `description`)
+VALUES ('tos:publish', 'Publish terms of service versions');
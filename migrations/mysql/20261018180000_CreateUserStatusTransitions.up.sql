@@ -0,0 +1,37 @@
+ALTER TABLE `users`
+    ADD COLUMN `suspended_at`     TIMESTAMP    NULL AFTER `is_active`,
+    ADD COLUMN `suspended_until`  TIMESTAMP    NULL AFTER `suspended_at`,
+    ADD COLUMN `suspended_reason` VARCHAR(500) NOT NULL DEFAULT '' AFTER `suspended_until`;
+
+-- is_active was never set before, authentication now rejects inactive accounts
+-- so existing accounts are activated to keep them working
+UPDATE `users`
+SET `is_active` = 1
+WHERE `deleted_at` IS NULL;
+
+CREATE TABLE `user_status_transitions`
+(
+    `id`            INT unsigned NOT NULL AUTO_INCREMENT,
+    `user_id`       INT unsigned NOT NULL,
+    `actor_user_id` INT unsigned NULL,
+    `from_status`   VARCHAR(20)  NOT NULL,
+    `to_status`     VARCHAR(20)  NOT NULL,
+    `reason`        VARCHAR(500) NOT NULL DEFAULT '',
+    `expires_at`    TIMESTAMP    NULL,
+    `created_at`    TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX `user_status_transitions_user_id_idx` (`user_id` ASC),
+    CONSTRAINT `fk_user_status_transitions_user_id`
+        FOREIGN KEY (`user_id`)
+            REFERENCES `users` (`id`)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE,
+    CONSTRAINT `fk_user_status_transitions_actor_user_id`
+        FOREIGN KEY (`actor_user_id`)
+            REFERENCES `users` (`id`)
+            ON DELETE SET NULL
+            ON UPDATE CASCADE
+) ENGINE = InnoDB;
+
+INSERT INTO `permissions` (`name`, `description`)
+VALUES ('users:status', 'Activate, deactivate, suspend and reactivate users');
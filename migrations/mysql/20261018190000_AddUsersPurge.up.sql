@@ -0,0 +1,5 @@
+ALTER TABLE `users`
+    ADD INDEX `users_deleted_at_idx` (`deleted_at` ASC);
+
+INSERT INTO `permissions` (`name`, `description`)
+VALUES ('users:purge', 'Permanently delete users');
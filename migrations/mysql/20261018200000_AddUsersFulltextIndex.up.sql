@@ -0,0 +1,2 @@
+ALTER TABLE `users`
+    ADD FULLTEXT INDEX `users_fulltext_idx` (`first_name`, `last_name`, `email`, `city`, `bio`);
@@ -0,0 +1,2 @@
+INSERT INTO `permissions` (`name`, `description`)
+VALUES ('users:private', 'Read private fields and account state of all users');
@@ -0,0 +1,10 @@
+INSERT INTO `permissions` (`name`, `description`)
+VALUES ('users:search', 'Search users');
+
+-- search was served to users allowed to read users, they keep it
+INSERT INTO `role_permissions` (`role_id`, `permission_id`)
+SELECT `role_permissions`.`role_id`, `search`.`id`
+FROM `role_permissions`
+         JOIN `permissions` AS `granted` ON `granted`.`id` = `role_permissions`.`permission_id`
+         JOIN `permissions` AS `search` ON `search`.`name` = 'users:search'
+WHERE `granted`.`name` = 'users:read';
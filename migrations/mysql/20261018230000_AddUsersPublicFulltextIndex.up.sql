@@ -0,0 +1,4 @@
+-- users without access to private fields search only public columns,
+-- MATCH columns have to match index columns exactly
+ALTER TABLE `users`
+    ADD FULLTEXT INDEX `users_public_fulltext_idx` (`first_name`, `last_name`, `city`, `bio`);
@@ -0,0 +1,34 @@
+CREATE TABLE users
+(
+    id                 SERIAL       NOT NULL,
+    first_name         VARCHAR(255) NOT NULL DEFAULT '',
+    last_name          VARCHAR(255) NOT NULL DEFAULT '',
+    profile_image      VARCHAR(255) NOT NULL DEFAULT '',
+    email              VARCHAR(120) NOT NULL,
+    is_email_verified  BOOLEAN      NULL     DEFAULT FALSE,
+    bio                TEXT         NOT NULL,
+    phone_number       VARCHAR(20)  NOT NULL DEFAULT '',
+    is_phone_verified  BOOLEAN      NULL     DEFAULT FALSE,
+    country            VARCHAR(255) NOT NULL DEFAULT '',
+    state              VARCHAR(255) NOT NULL DEFAULT '',
+    area               VARCHAR(255) NOT NULL DEFAULT '',
+    city               VARCHAR(255) NOT NULL DEFAULT '',
+    address            VARCHAR(255) NOT NULL DEFAULT '',
+    post_code          VARCHAR(50)  NOT NULL DEFAULT '',
+    birth_date         TIMESTAMP    NULL,
+    invited_by_user_id INTEGER      NULL,
+    tos_accepted       BOOLEAN      NULL     DEFAULT FALSE,
+    is_active          BOOLEAN      NULL     DEFAULT FALSE,
+    created_at         TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    deleted_at         TIMESTAMP    NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_invited_by_user_id
+        FOREIGN KEY (invited_by_user_id)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+            ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX users_email_idx ON users (email ASC);
+CREATE INDEX fk_invited_by_user_id_idx ON users (invited_by_user_id ASC);
@@ -0,0 +1,20 @@
+CREATE TABLE refresh_tokens
+(
+    id             SERIAL    NOT NULL,
+    user_id        INTEGER   NOT NULL,
+    token_hash     CHAR(64)  NOT NULL,
+    expires_at     TIMESTAMP NULL,
+    revoked_at     TIMESTAMP NULL,
+    replaced_by_id INTEGER   NULL,
+    created_at     TIMESTAMP          DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP          DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_refresh_tokens_user_id
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX refresh_tokens_token_hash_idx ON refresh_tokens (token_hash ASC);
+CREATE INDEX fk_refresh_tokens_user_id_idx ON refresh_tokens (user_id ASC);
@@ -0,0 +1,20 @@
+CREATE TABLE user_tokens
+(
+    id         SERIAL      NOT NULL,
+    user_id    INTEGER     NOT NULL,
+    purpose    VARCHAR(50) NOT NULL,
+    token_hash CHAR(64)    NOT NULL,
+    expires_at TIMESTAMP   NULL,
+    used_at    TIMESTAMP   NULL,
+    created_at TIMESTAMP            DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP            DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_user_tokens_user_id
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX user_tokens_token_hash_idx ON user_tokens (token_hash ASC);
+CREATE INDEX user_tokens_user_id_purpose_idx ON user_tokens (user_id ASC, purpose ASC);
@@ -0,0 +1,79 @@
+CREATE TABLE roles
+(
+    id          INTEGER      PRIMARY KEY AUTOINCREMENT,
+    name        VARCHAR(100) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at  TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP             DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX roles_name_idx ON roles (name ASC);
+
+CREATE TABLE permissions
+(
+    id          INTEGER      PRIMARY KEY AUTOINCREMENT,
+    name        VARCHAR(100) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at  TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP             DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX permissions_name_idx ON permissions (name ASC);
+
+CREATE TABLE role_permissions
+(
+    role_id       INTEGER NOT NULL,
+    permission_id INTEGER NOT NULL,
+    PRIMARY KEY (role_id, permission_id),
+    CONSTRAINT fk_role_permissions_role_id
+        FOREIGN KEY (role_id)
+            REFERENCES roles (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE,
+    CONSTRAINT fk_role_permissions_permission_id
+        FOREIGN KEY (permission_id)
+            REFERENCES permissions (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE
+);
+
+CREATE INDEX fk_role_permissions_permission_id_idx ON role_permissions (permission_id ASC);
+
+CREATE TABLE user_roles
+(
+    user_id    INTEGER   NOT NULL,
+    role_id    INTEGER   NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role_id),
+    CONSTRAINT fk_user_roles_user_id
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE,
+    CONSTRAINT fk_user_roles_role_id
+        FOREIGN KEY (role_id)
+            REFERENCES roles (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE
+);
+
+CREATE INDEX fk_user_roles_role_id_idx ON user_roles (role_id ASC);
+
+INSERT INTO roles (name, description)
+VALUES ('admin', 'Full access to all resources'),
+       ('user', 'Default role for registered users');
+
+INSERT INTO permissions (name, description)
+VALUES ('*', 'All permissions'),
+       ('users:read', 'List and read users'),
+       ('users:create', 'Create users'),
+       ('users:update', 'Update users'),
+       ('users:delete', 'Delete users'),
+       ('roles:assign', 'Assign and revoke user roles');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id
+FROM roles,
+     permissions
+WHERE roles.name = 'admin'
+  AND permissions.name = '*';
@@ -0,0 +1,20 @@
+CREATE TABLE one_time_codes
+(
+    id          INTEGER      PRIMARY KEY AUTOINCREMENT,
+    user_id     INTEGER      NOT NULL,
+    purpose     VARCHAR(50)  NOT NULL,
+    destination VARCHAR(255) NOT NULL DEFAULT '',
+    code_hash   CHAR(64)     NOT NULL,
+    attempts    INTEGER      NOT NULL DEFAULT 0,
+    expires_at  TIMESTAMP    NULL,
+    used_at     TIMESTAMP    NULL,
+    created_at  TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_one_time_codes_user_id
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE
+);
+
+CREATE INDEX one_time_codes_user_id_purpose_idx ON one_time_codes (user_id ASC, purpose ASC);
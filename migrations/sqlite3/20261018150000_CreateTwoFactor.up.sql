@@ -0,0 +1,32 @@
+CREATE TABLE two_factor_credentials
+(
+    user_id          INTEGER      NOT NULL,
+    secret_encrypted VARCHAR(255) NOT NULL,
+    enabled_at       TIMESTAMP    NULL,
+    last_used_step   BIGINT       NOT NULL DEFAULT 0,
+    created_at       TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id),
+    CONSTRAINT fk_two_factor_credentials_user_id
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE
+);
+
+CREATE TABLE two_factor_recovery_codes
+(
+    id         INTEGER   PRIMARY KEY AUTOINCREMENT,
+    user_id    INTEGER   NOT NULL,
+    code_hash  CHAR(64)  NOT NULL,
+    used_at    TIMESTAMP NULL,
+    created_at TIMESTAMP          DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP          DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_two_factor_recovery_codes_user_id
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX two_factor_recovery_codes_user_id_code_hash_idx ON two_factor_recovery_codes (user_id ASC, code_hash ASC);
@@ -0,0 +1,39 @@
+CREATE TABLE invitations
+(
+    id               INTEGER      PRIMARY KEY AUTOINCREMENT,
+    inviter_user_id  INTEGER      NOT NULL,
+    email            VARCHAR(120) NOT NULL,
+    token_hash       CHAR(64)     NOT NULL,
+    status           VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    expires_at       TIMESTAMP    NULL,
+    accepted_user_id INTEGER      NULL,
+    accepted_at      TIMESTAMP    NULL,
+    revoked_at       TIMESTAMP    NULL,
+    created_at       TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_invitations_inviter_user_id
+        FOREIGN KEY (inviter_user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE,
+    CONSTRAINT fk_invitations_accepted_user_id
+        FOREIGN KEY (accepted_user_id)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+            ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX invitations_token_hash_idx ON invitations (token_hash ASC);
+CREATE INDEX invitations_email_status_idx ON invitations (email ASC, status ASC);
+CREATE INDEX fk_invitations_inviter_user_id_idx ON invitations (inviter_user_id ASC);
+CREATE INDEX fk_invitations_accepted_user_id_idx ON invitations (accepted_user_id ASC);
+
+INSERT INTO permissions (name, description)
+VALUES ('invitations:create', 'Invite new users');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id
+FROM roles,
+     permissions
+WHERE roles.name = 'user'
+  AND permissions.name = 'invitations:create';
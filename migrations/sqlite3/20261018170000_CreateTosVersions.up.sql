@@ -0,0 +1,40 @@
+CREATE TABLE tos_versions
+(
+    id           INTEGER       PRIMARY KEY AUTOINCREMENT,
+    version      VARCHAR(50)   NOT NULL,
+    summary      VARCHAR(1000) NOT NULL DEFAULT '',
+    url          VARCHAR(512)  NOT NULL DEFAULT '',
+    published_at TIMESTAMP     NULL,
+    created_at   TIMESTAMP              DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP              DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX tos_versions_version_idx ON tos_versions (version ASC);
+CREATE INDEX tos_versions_published_at_idx ON tos_versions (published_at ASC);
+
+CREATE TABLE user_tos_acceptances
+(
+    id             INTEGER      PRIMARY KEY AUTOINCREMENT,
+    user_id        INTEGER      NOT NULL,
+    tos_version_id INTEGER      NOT NULL,
+    ip_address     VARCHAR(45)  NOT NULL DEFAULT '',
+    user_agent     VARCHAR(512) NOT NULL DEFAULT '',
+    accepted_at    TIMESTAMP    NULL,
+    created_at     TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_tos_acceptances_user_id
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE,
+    CONSTRAINT fk_user_tos_acceptances_tos_version_id
+        FOREIGN KEY (tos_version_id)
+            REFERENCES tos_versions (id)
+            ON DELETE RESTRICT
+            ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX user_tos_acceptances_user_id_tos_version_id_idx ON user_tos_acceptances (user_id ASC, tos_version_id ASC);
+CREATE INDEX fk_user_tos_acceptances_tos_version_id_idx ON user_tos_acceptances (tos_version_id ASC);
+
+INSERT INTO permissions (name, description)
+VALUES ('tos:publish', 'Publish terms of service versions');
@@ -0,0 +1,39 @@
+ALTER TABLE users
+    ADD COLUMN suspended_at     TIMESTAMP    NULL;
+ALTER TABLE users
+    ADD COLUMN suspended_until  TIMESTAMP    NULL;
+ALTER TABLE users
+    ADD COLUMN suspended_reason VARCHAR(500) NOT NULL DEFAULT '';
+
+-- is_active was never set before, authentication now rejects inactive accounts
+-- so existing accounts are activated to keep them working
+UPDATE users
+SET is_active = 1
+WHERE deleted_at IS NULL;
+
+CREATE TABLE user_status_transitions
+(
+    id            INTEGER      PRIMARY KEY AUTOINCREMENT,
+    user_id       INTEGER      NOT NULL,
+    actor_user_id INTEGER      NULL,
+    from_status   VARCHAR(20)  NOT NULL,
+    to_status     VARCHAR(20)  NOT NULL,
+    reason        VARCHAR(500) NOT NULL DEFAULT '',
+    expires_at    TIMESTAMP    NULL,
+    created_at    TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_status_transitions_user_id
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE,
+    CONSTRAINT fk_user_status_transitions_actor_user_id
+        FOREIGN KEY (actor_user_id)
+            REFERENCES users (id)
+            ON DELETE SET NULL
+            ON UPDATE CASCADE
+);
+
+CREATE INDEX user_status_transitions_user_id_idx ON user_status_transitions (user_id ASC);
+
+INSERT INTO permissions (name, description)
+VALUES ('users:status', 'Activate, deactivate, suspend and reactivate users');
@@ -0,0 +1,4 @@
+CREATE INDEX users_deleted_at_idx ON users (deleted_at ASC);
+
+INSERT INTO permissions (name, description)
+VALUES ('users:purge', 'Permanently delete users');
@@ -0,0 +1,83 @@
+package mocks
+
+import (
+	"context"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// NewInvitationRepositoryMock creates new InvitationRepository mocked implementation
+func NewInvitationRepositoryMock() *InvitationRepositoryMock {
+	return &InvitationRepositoryMock{}
+}
+
+// InvitationRepositoryMock is a mocked object that implements repositories.InvitationRepository interface
+type InvitationRepositoryMock struct {
+	mock.Mock
+}
+
+// InvitationRepository ensures interface implementation
+func (repo *InvitationRepositoryMock) InvitationRepository() string {
+	args := repo.Called()
+	return args.String(0)
+}
+
+// GetByID returns invitation model based on a provided id
+func (repo *InvitationRepositoryMock) GetByID(ctx context.Context, id uint64) (*models.Invitation, error) {
+	args := repo.Called(ctx, id)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.(*models.Invitation), err
+}
+
+// GetByTokenHash returns invitation model based on a provided token hash
+func (repo *InvitationRepositoryMock) GetByTokenHash(ctx context.Context, tokenHash string) (*models.Invitation, error) {
+	args := repo.Called(ctx, tokenHash)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.(*models.Invitation), err
+}
+
+// GetAllByInviterUserID returns all invitations sent by given user
+func (repo *InvitationRepositoryMock) GetAllByInviterUserID(ctx context.Context, inviterUserID uint64) ([]*models.Invitation, error) {
+	args := repo.Called(ctx, inviterUserID)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.([]*models.Invitation), err
+}
+
+// CountPendingByEmail returns number of pending invitations for given email
+func (repo *InvitationRepositoryMock) CountPendingByEmail(ctx context.Context, email string) (int, error) {
+	args := repo.Called(ctx, email)
+
+	return args.Int(0), args.Error(1)
+}
+
+// Create new Invitation object in database
+func (repo *InvitationRepositoryMock) Create(ctx context.Context, invitation *models.Invitation) error {
+	args := repo.Called(ctx, invitation)
+
+	return args.Error(0)
+}
+
+// UpdateStatus changes invitation status if it is still pending
+func (repo *InvitationRepositoryMock) UpdateStatus(ctx context.Context, invitation *models.Invitation, status string) (bool, error) {
+	args := repo.Called(ctx, invitation, status)
+
+	return args.Bool(0), args.Error(1)
+}
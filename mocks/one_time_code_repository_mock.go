@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"context"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// NewOneTimeCodeRepositoryMock creates new OneTimeCodeRepository mocked implementation
+func NewOneTimeCodeRepositoryMock() *OneTimeCodeRepositoryMock {
+	return &OneTimeCodeRepositoryMock{}
+}
+
+// OneTimeCodeRepositoryMock is a mocked object that implements repositories.OneTimeCodeRepository interface
+type OneTimeCodeRepositoryMock struct {
+	mock.Mock
+}
+
+// OneTimeCodeRepository ensures interface implementation
+func (repo *OneTimeCodeRepositoryMock) OneTimeCodeRepository() string {
+	args := repo.Called()
+	return args.String(0)
+}
+
+// GetLatestByUserID returns most recently created one time code for given purpose
+func (repo *OneTimeCodeRepositoryMock) GetLatestByUserID(ctx context.Context, userID uint64, purpose string) (*models.OneTimeCode, error) {
+	args := repo.Called(ctx, userID, purpose)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.(*models.OneTimeCode), err
+}
+
+// Create new OneTimeCode object in database
+func (repo *OneTimeCodeRepositoryMock) Create(ctx context.Context, code *models.OneTimeCode) error {
+	args := repo.Called(ctx, code)
+
+	return args.Error(0)
+}
+
+// IncrementAttempts counts verification attempt
+func (repo *OneTimeCodeRepositoryMock) IncrementAttempts(ctx context.Context, code *models.OneTimeCode, maxAttempts int) (bool, error) {
+	args := repo.Called(ctx, code, maxAttempts)
+
+	return args.Bool(0), args.Error(1)
+}
+
+// MarkUsed marks one time code as used
+func (repo *OneTimeCodeRepositoryMock) MarkUsed(ctx context.Context, code *models.OneTimeCode) (bool, error) {
+	args := repo.Called(ctx, code)
+
+	return args.Bool(0), args.Error(1)
+}
+
+// InvalidateAllByUserID marks all unused one time codes for given purpose as used
+func (repo *OneTimeCodeRepositoryMock) InvalidateAllByUserID(ctx context.Context, userID uint64, purpose string) error {
+	args := repo.Called(ctx, userID, purpose)
+
+	return args.Error(0)
+}
@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"context"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// NewRefreshTokenRepositoryMock creates new RefreshTokenRepository mocked implementation
+func NewRefreshTokenRepositoryMock() *RefreshTokenRepositoryMock {
+	return &RefreshTokenRepositoryMock{}
+}
+
+// RefreshTokenRepositoryMock is a mocked object that implements repositories.RefreshTokenRepository interface
+type RefreshTokenRepositoryMock struct {
+	mock.Mock
+}
+
+// RefreshTokenRepository ensures interface implementation
+func (repo *RefreshTokenRepositoryMock) RefreshTokenRepository() string {
+	args := repo.Called()
+	return args.String(0)
+}
+
+// GetByTokenHash returns refresh token model based on a provided token hash
+func (repo *RefreshTokenRepositoryMock) GetByTokenHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	args := repo.Called(ctx, tokenHash)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.(*models.RefreshToken), err
+}
+
+// Create new RefreshToken object in database
+func (repo *RefreshTokenRepositoryMock) Create(ctx context.Context, token *models.RefreshToken) error {
+	args := repo.Called(ctx, token)
+
+	return args.Error(0)
+}
+
+// Update existing RefreshToken object in database
+func (repo *RefreshTokenRepositoryMock) Update(ctx context.Context, token *models.RefreshToken) error {
+	args := repo.Called(ctx, token)
+
+	return args.Error(0)
+}
+
+// Rotate revokes refresh token as replaced by token with given id
+func (repo *RefreshTokenRepositoryMock) Rotate(ctx context.Context, token *models.RefreshToken, replacedByID uint64) (bool, error) {
+	args := repo.Called(ctx, token, replacedByID)
+
+	return args.Bool(0), args.Error(1)
+}
+
+// RevokeAllByUserID revokes all active refresh tokens issued to given user
+func (repo *RefreshTokenRepositoryMock) RevokeAllByUserID(ctx context.Context, userID uint64) error {
+	args := repo.Called(ctx, userID)
+
+	return args.Error(0)
+}
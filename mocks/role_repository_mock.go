@@ -0,0 +1,76 @@
+package mocks
+
+import (
+	"context"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// NewRoleRepositoryMock creates new RoleRepository mocked implementation
+func NewRoleRepositoryMock() *RoleRepositoryMock {
+	return &RoleRepositoryMock{}
+}
+
+// RoleRepositoryMock is a mocked object that implements repositories.RoleRepository interface
+type RoleRepositoryMock struct {
+	mock.Mock
+}
+
+// RoleRepository ensures interface implementation
+func (repo *RoleRepositoryMock) RoleRepository() string {
+	args := repo.Called()
+	return args.String(0)
+}
+
+// GetByName returns role model based on a provided name
+func (repo *RoleRepositoryMock) GetByName(ctx context.Context, name string) (*models.Role, error) {
+	args := repo.Called(ctx, name)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.(*models.Role), err
+}
+
+// GetByUserID returns all roles assigned to given user
+func (repo *RoleRepositoryMock) GetByUserID(ctx context.Context, userID uint64) ([]*models.Role, error) {
+	args := repo.Called(ctx, userID)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.([]*models.Role), err
+}
+
+// GetPermissionNamesByUserID returns names of all permissions granted to given user
+func (repo *RoleRepositoryMock) GetPermissionNamesByUserID(ctx context.Context, userID uint64) ([]string, error) {
+	args := repo.Called(ctx, userID)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.([]string), err
+}
+
+// AssignToUser assigns role to user
+func (repo *RoleRepositoryMock) AssignToUser(ctx context.Context, userID uint64, roleID uint64) error {
+	args := repo.Called(ctx, userID, roleID)
+
+	return args.Error(0)
+}
+
+// RemoveFromUser removes role from user
+func (repo *RoleRepositoryMock) RemoveFromUser(ctx context.Context, userID uint64, roleID uint64) error {
+	args := repo.Called(ctx, userID, roleID)
+
+	return args.Error(0)
+}
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// NewSearchIndexMock creates new SearchIndex mocked implementation
+func NewSearchIndexMock() *SearchIndexMock {
+	return &SearchIndexMock{}
+}
+
+// SearchIndexMock is a mocked object that implements repositories.SearchIndex interface
+type SearchIndexMock struct {
+	mock.Mock
+}
+
+// SearchIndex ensures interface implementation
+func (ix *SearchIndexMock) SearchIndex() string {
+	args := ix.Called()
+	return args.String(0)
+}
+
+// Search returns at most limit users matching query, ordered by relevance
+func (ix *SearchIndexMock) Search(ctx context.Context, query string, limit int, private bool) ([]*models.UserSearchResult, error) {
+	args := ix.Called(ctx, query, limit, private)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.([]*models.UserSearchResult), err
+}
+
+// Index adds user to index or replaces already indexed user
+func (ix *SearchIndexMock) Index(ctx context.Context, user *models.User) error {
+	args := ix.Called(ctx, user)
+
+	return args.Error(0)
+}
+
+// Remove removes user with given id from index
+func (ix *SearchIndexMock) Remove(ctx context.Context, userID uint64) error {
+	args := ix.Called(ctx, userID)
+
+	return args.Error(0)
+}
@@ -0,0 +1,76 @@
+package mocks
+
+import (
+	"context"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// NewTosRepositoryMock creates new TosRepository mocked implementation
+func NewTosRepositoryMock() *TosRepositoryMock {
+	return &TosRepositoryMock{}
+}
+
+// TosRepositoryMock is a mocked object that implements repositories.TosRepository interface
+type TosRepositoryMock struct {
+	mock.Mock
+}
+
+// TosRepository ensures interface implementation
+func (repo *TosRepositoryMock) TosRepository() string {
+	args := repo.Called()
+	return args.String(0)
+}
+
+// GetCurrent returns most recently published terms of service version
+func (repo *TosRepositoryMock) GetCurrent(ctx context.Context) (*models.TosVersion, error) {
+	args := repo.Called(ctx)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.(*models.TosVersion), err
+}
+
+// Create new TosVersion object in database
+func (repo *TosRepositoryMock) Create(ctx context.Context, version *models.TosVersion) error {
+	args := repo.Called(ctx, version)
+
+	return args.Error(0)
+}
+
+// GetAcceptance returns acceptance of given terms of service version by given user
+func (repo *TosRepositoryMock) GetAcceptance(ctx context.Context, userID uint64, tosVersionID uint64) (*models.UserTosAcceptance, error) {
+	args := repo.Called(ctx, userID, tosVersionID)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.(*models.UserTosAcceptance), err
+}
+
+// GetAcceptancesByUserID returns terms of service acceptance history of given user
+func (repo *TosRepositoryMock) GetAcceptancesByUserID(ctx context.Context, userID uint64) ([]*models.UserTosAcceptance, error) {
+	args := repo.Called(ctx, userID)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.([]*models.UserTosAcceptance), err
+}
+
+// CreateAcceptance creates new UserTosAcceptance object in database
+func (repo *TosRepositoryMock) CreateAcceptance(ctx context.Context, acceptance *models.UserTosAcceptance) error {
+	args := repo.Called(ctx, acceptance)
+
+	return args.Error(0)
+}
@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"context"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// NewTwoFactorRepositoryMock creates new TwoFactorRepository mocked implementation
+func NewTwoFactorRepositoryMock() *TwoFactorRepositoryMock {
+	return &TwoFactorRepositoryMock{}
+}
+
+// TwoFactorRepositoryMock is a mocked object that implements repositories.TwoFactorRepository interface
+type TwoFactorRepositoryMock struct {
+	mock.Mock
+}
+
+// TwoFactorRepository ensures interface implementation
+func (repo *TwoFactorRepositoryMock) TwoFactorRepository() string {
+	args := repo.Called()
+	return args.String(0)
+}
+
+// GetByUserID returns two-factor credential of given user
+func (repo *TwoFactorRepositoryMock) GetByUserID(ctx context.Context, userID uint64) (*models.TwoFactorCredential, error) {
+	args := repo.Called(ctx, userID)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.(*models.TwoFactorCredential), err
+}
+
+// Save creates or updates two-factor credential
+func (repo *TwoFactorRepositoryMock) Save(ctx context.Context, credential *models.TwoFactorCredential) error {
+	args := repo.Called(ctx, credential)
+
+	return args.Error(0)
+}
+
+// UpdateLastUsedStep stores last accepted time step
+func (repo *TwoFactorRepositoryMock) UpdateLastUsedStep(ctx context.Context, credential *models.TwoFactorCredential, step int64) (bool, error) {
+	args := repo.Called(ctx, credential, step)
+
+	return args.Bool(0), args.Error(1)
+}
+
+// DeleteByUserID removes two-factor credential and recovery codes of given user
+func (repo *TwoFactorRepositoryMock) DeleteByUserID(ctx context.Context, userID uint64) error {
+	args := repo.Called(ctx, userID)
+
+	return args.Error(0)
+}
+
+// ReplaceRecoveryCodes removes existing recovery codes of given user and stores new ones
+func (repo *TwoFactorRepositoryMock) ReplaceRecoveryCodes(ctx context.Context, userID uint64, codes []*models.TwoFactorRecoveryCode) error {
+	args := repo.Called(ctx, userID, codes)
+
+	return args.Error(0)
+}
+
+// MarkRecoveryCodeUsed marks unused recovery code as used
+func (repo *TwoFactorRepositoryMock) MarkRecoveryCodeUsed(ctx context.Context, userID uint64, codeHash string) (bool, error) {
+	args := repo.Called(ctx, userID, codeHash)
+
+	return args.Bool(0), args.Error(1)
+}
@@ -1,6 +1,9 @@
 package mocks
 
 import (
+	"context"
+	"time"
+
 	"github.com/go-flow/template-api/domain/models"
 	"github.com/go-flow/template-api/pkg/paging"
 	"github.com/stretchr/testify/mock"
@@ -24,8 +27,21 @@ func (repo *UserRepositoryMock) UserRepository() string {
 }
 
 // GetByID returns user model based on a provided id
-func (repo *UserRepositoryMock) GetByID(id uint64) (*models.User, error) {
-	args := repo.Called(id)
+func (repo *UserRepositoryMock) GetByID(ctx context.Context, id uint64) (*models.User, error) {
+	args := repo.Called(ctx, id)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.(*models.User), err
+}
+
+// GetByIDWithFields returns user model based on a provided id with only fieldset columns loaded
+func (repo *UserRepositoryMock) GetByIDWithFields(ctx context.Context, id uint64, fields paging.Fields) (*models.User, error) {
+	args := repo.Called(ctx, id, fields)
 
 	model := args.Get(0)
 	err := args.Error(1)
@@ -37,8 +53,8 @@ func (repo *UserRepositoryMock) GetByID(id uint64) (*models.User, error) {
 }
 
 // GetByEmail returns user model based on a provided email
-func (repo *UserRepositoryMock) GetByEmail(email string) (*models.User, error) {
-	args := repo.Called(email)
+func (repo *UserRepositoryMock) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	args := repo.Called(ctx, email)
 
 	model := args.Get(0)
 	err := args.Error(1)
@@ -51,8 +67,20 @@ func (repo *UserRepositoryMock) GetByEmail(email string) (*models.User, error) {
 }
 
 // GetAll returns all Users model for given paging users
-func (repo *UserRepositoryMock) GetAll(paginator *paging.Paginator) ([]*models.User, error) {
-	args := repo.Called(paginator)
+func (repo *UserRepositoryMock) GetAll(ctx context.Context, paginator *paging.Paginator) ([]*models.User, error) {
+	args := repo.Called(ctx, paginator)
+
+	model := args.Get(0)
+	err := args.Error(1)
+	if model == nil {
+		return nil, err
+	}
+	return model.([]*models.User), err
+}
+
+// GetAllByInvitedByUserIDs returns all users invited by any of given users
+func (repo *UserRepositoryMock) GetAllByInvitedByUserIDs(ctx context.Context, inviterIDs []uint64) ([]*models.User, error) {
+	args := repo.Called(ctx, inviterIDs)
 
 	model := args.Get(0)
 	err := args.Error(1)
@@ -63,36 +91,57 @@ func (repo *UserRepositoryMock) GetAll(paginator *paging.Paginator) ([]*models.U
 }
 
 // Create new User object in database
-func (repo *UserRepositoryMock) Create(user *models.User) error {
-	args := repo.Called(user)
+func (repo *UserRepositoryMock) Create(ctx context.Context, user *models.User) error {
+	args := repo.Called(ctx, user)
 
 	return args.Error(0)
 }
 
 // Update existing User object in database
-func (repo *UserRepositoryMock) Update(user *models.User) error {
-	args := repo.Called(user)
+func (repo *UserRepositoryMock) Update(ctx context.Context, user *models.User) error {
+	args := repo.Called(ctx, user)
 
 	return args.Error(0)
 }
 
 // Save creates or updates user model based on ID user
-func (repo *UserRepositoryMock) Save(user *models.User) error {
+func (repo *UserRepositoryMock) Save(ctx context.Context, user *models.User) error {
 	if user.ID > 0 {
-		return repo.Update(user)
+		return repo.Update(ctx, user)
 	}
 
-	return repo.Create(user)
+	return repo.Create(ctx, user)
 }
 
-// Delete user record from database
-func (repo *UserRepositoryMock) Delete(user *models.User) error {
-	return repo.DeleteByID(user.ID)
+// Delete soft deletes user record
+func (repo *UserRepositoryMock) Delete(ctx context.Context, user *models.User) error {
+	return repo.DeleteByID(ctx, user.ID)
+}
+
+// DeleteByID soft deletes user record
+func (repo *UserRepositoryMock) DeleteByID(ctx context.Context, id uint64) error {
+	args := repo.Called(ctx, id)
+
+	return args.Error(0)
 }
 
-// DeleteByID user record from database
-func (repo *UserRepositoryMock) DeleteByID(id uint64) error {
-	args := repo.Called(id)
+// Restore clears deletion of soft deleted user record
+func (repo *UserRepositoryMock) Restore(ctx context.Context, id uint64) error {
+	args := repo.Called(ctx, id)
 
 	return args.Error(0)
 }
+
+// Purge permanently deletes user record
+func (repo *UserRepositoryMock) Purge(ctx context.Context, id uint64) error {
+	args := repo.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+// PurgeDeletedBefore permanently deletes user records soft deleted before given time
+func (repo *UserRepositoryMock) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := repo.Called(ctx, before)
+
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// NewUserStatusTransitionRepositoryMock creates new UserStatusTransitionRepository mocked implementation
+func NewUserStatusTransitionRepositoryMock() *UserStatusTransitionRepositoryMock {
+	return &UserStatusTransitionRepositoryMock{}
+}
+
+// UserStatusTransitionRepositoryMock is a mocked object that implements repositories.UserStatusTransitionRepository interface
+type UserStatusTransitionRepositoryMock struct {
+	mock.Mock
+}
+
+// UserStatusTransitionRepository ensures interface implementation
+func (repo *UserStatusTransitionRepositoryMock) UserStatusTransitionRepository() string {
+	args := repo.Called()
+	return args.String(0)
+}
+
+// GetAllByUserID returns status transitions of given user
+func (repo *UserStatusTransitionRepositoryMock) GetAllByUserID(ctx context.Context, userID uint64) ([]*models.UserStatusTransition, error) {
+	args := repo.Called(ctx, userID)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.([]*models.UserStatusTransition), err
+}
+
+// Create new UserStatusTransition object in database
+func (repo *UserStatusTransitionRepositoryMock) Create(ctx context.Context, transition *models.UserStatusTransition) error {
+	args := repo.Called(ctx, transition)
+
+	return args.Error(0)
+}
@@ -0,0 +1,70 @@
+package mocks
+
+import (
+	"context"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// NewUserTokenRepositoryMock creates new UserTokenRepository mocked implementation
+func NewUserTokenRepositoryMock() *UserTokenRepositoryMock {
+	return &UserTokenRepositoryMock{}
+}
+
+// UserTokenRepositoryMock is a mocked object that implements repositories.UserTokenRepository interface
+type UserTokenRepositoryMock struct {
+	mock.Mock
+}
+
+// UserTokenRepository ensures interface implementation
+func (repo *UserTokenRepositoryMock) UserTokenRepository() string {
+	args := repo.Called()
+	return args.String(0)
+}
+
+// GetByTokenHash returns user token model based on a provided purpose and token hash
+func (repo *UserTokenRepositoryMock) GetByTokenHash(ctx context.Context, purpose string, tokenHash string) (*models.UserToken, error) {
+	args := repo.Called(ctx, purpose, tokenHash)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.(*models.UserToken), err
+}
+
+// GetLatestByUserID returns most recently created user token for given purpose
+func (repo *UserTokenRepositoryMock) GetLatestByUserID(ctx context.Context, userID uint64, purpose string) (*models.UserToken, error) {
+	args := repo.Called(ctx, userID, purpose)
+
+	model := args.Get(0)
+	err := args.Error(1)
+
+	if model == nil {
+		return nil, err
+	}
+	return model.(*models.UserToken), err
+}
+
+// Create new UserToken object in database
+func (repo *UserTokenRepositoryMock) Create(ctx context.Context, token *models.UserToken) error {
+	args := repo.Called(ctx, token)
+
+	return args.Error(0)
+}
+
+// MarkUsed marks user token as used
+func (repo *UserTokenRepositoryMock) MarkUsed(ctx context.Context, token *models.UserToken) (bool, error) {
+	args := repo.Called(ctx, token)
+
+	return args.Bool(0), args.Error(1)
+}
+
+// InvalidateAllByUserID marks all unused user tokens for given purpose as used
+func (repo *UserTokenRepositoryMock) InvalidateAllByUserID(ctx context.Context, userID uint64, purpose string) error {
+	args := repo.Called(ctx, userID, purpose)
+
+	return args.Error(0)
+}
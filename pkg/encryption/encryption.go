@@ -0,0 +1,73 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+// ErrInvalidCiphertext is returned when ciphertext cannot be decoded or authenticated
+var ErrInvalidCiphertext = errors.New("encryption: invalid ciphertext")
+
+// Encrypter encrypts short values, such as secrets, for storing at rest
+//
+// values are sealed with AES-256-GCM, key is derived from configured key with SHA-256
+// so any non empty string can be used as application key
+type Encrypter struct {
+	aead cipher.AEAD
+}
+
+// NewEncrypter creates Encrypter instance for given key
+func NewEncrypter(key string) (*Encrypter, error) {
+	if key == "" {
+		return nil, errors.New("encryption: key is empty")
+	}
+
+	sum := sha256.Sum256([]byte(key))
+
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Encrypter{aead: aead}, nil
+}
+
+// Encrypt returns base64 encoded nonce and ciphertext of given value
+func (e *Encrypter) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := e.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns plaintext of value produced by Encrypt
+func (e *Encrypter) Decrypt(ciphertext string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	size := e.aead.NonceSize()
+	if len(sealed) < size {
+		return "", ErrInvalidCiphertext
+	}
+
+	plain, err := e.aead.Open(nil, sealed[:size], sealed[size:], nil)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	return string(plain), nil
+}
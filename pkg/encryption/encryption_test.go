@@ -0,0 +1,38 @@
+package encryption
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EncryptDecrypt(t *testing.T) {
+	e, err := NewEncrypter("key")
+	assert.Nil(t, err, "expected error to be <nil>, got %v", err)
+
+	ciphertext, err := e.Encrypt("JBSWY3DPEHPK3PXP")
+	assert.Nil(t, err, "expected error to be <nil>, got %v", err)
+	assert.NotContains(t, ciphertext, "JBSWY3DPEHPK3PXP", "expected plaintext not to be visible in ciphertext")
+
+	plain, err := e.Decrypt(ciphertext)
+	assert.Nil(t, err, "expected error to be <nil>, got %v", err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", plain)
+}
+
+func Test_Decrypt_WrongKey(t *testing.T) {
+	e, _ := NewEncrypter("key")
+	other, _ := NewEncrypter("other")
+
+	ciphertext, _ := e.Encrypt("secret")
+
+	_, err := other.Decrypt(ciphertext)
+	assert.Equal(t, ErrInvalidCiphertext, err, "expected ciphertext sealed with different key to be rejected")
+
+	_, err = e.Decrypt("not base64!")
+	assert.Equal(t, ErrInvalidCiphertext, err, "expected malformed ciphertext to be rejected")
+}
+
+func Test_NewEncrypter_EmptyKey(t *testing.T) {
+	_, err := NewEncrypter("")
+	assert.NotNil(t, err, "expected empty key to be rejected")
+}
@@ -0,0 +1,153 @@
+// Package i18n provides message catalogs, language negotiation
+// and localized validation messages
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Catalog holds translated messages grouped by locale
+//
+// nested catalog objects are flattened to dot separated keys,
+// e.g. `{"errors": {"1001": "..."}}` is stored under `errors.1001`
+type Catalog struct {
+	messages map[string]map[string]string
+}
+
+// NewCatalog creates empty catalog
+func NewCatalog() *Catalog {
+	return &Catalog{messages: map[string]map[string]string{}}
+}
+
+// LoadDir creates catalog from `.json`, `.yaml` and `.yml` files in dir
+//
+// locale is taken from file name, e.g. `en.json` or `fr-CA.yaml`
+func LoadDir(dir string) (*Catalog, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewCatalog()
+	for _, f := range files {
+		ext := filepath.Ext(f.Name())
+		if f.IsDir() || (ext != ".json" && ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		buf, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.Parse(strings.TrimSuffix(f.Name(), ext), ext, buf); err != nil {
+			return nil, fmt.Errorf("unable to parse catalog `%s`: %v", f.Name(), err)
+		}
+	}
+
+	return c, nil
+}
+
+// Parse adds messages from JSON or YAML encoded buf, ext selects decoder
+func (c *Catalog) Parse(locale string, ext string, buf []byte) error {
+	var data interface{}
+
+	switch ext {
+	case ".json":
+		if err := json.Unmarshal(buf, &data); err != nil {
+			return err
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(buf, &data); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported catalog format `%s`", ext)
+	}
+
+	messages := map[string]string{}
+	if err := flatten("", data, messages); err != nil {
+		return err
+	}
+
+	c.Add(locale, messages)
+	return nil
+}
+
+// Add adds messages to locale, existing keys are overwritten
+func (c *Catalog) Add(locale string, messages map[string]string) {
+	locale = Normalize(locale)
+
+	m, ok := c.messages[locale]
+	if !ok {
+		m = map[string]string{}
+		c.messages[locale] = m
+	}
+
+	for k, v := range messages {
+		m[k] = v
+	}
+}
+
+// Locales returns sorted list of locales catalog has messages for
+func (c *Catalog) Locales() []string {
+	locales := make([]string, 0, len(c.messages))
+	for l := range c.messages {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Lookup returns message for key in first locale of chain which defines it
+func (c *Catalog) Lookup(chain []string, key string) (string, bool) {
+	for _, l := range chain {
+		if msg, ok := c.messages[Normalize(l)][key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// flatten copies scalar values of decoded catalog to out under dot separated keys
+func flatten(prefix string, value interface{}, out map[string]string) error {
+	join := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + "." + k
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		for k, child := range v {
+			if err := flatten(join(k), child, out); err != nil {
+				return err
+			}
+		}
+	case map[interface{}]interface{}:
+		for k, child := range v {
+			if err := flatten(join(fmt.Sprint(k)), child, out); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		return fmt.Errorf("key `%s` holds list, messages must be strings", prefix)
+	default:
+		if prefix == "" {
+			return fmt.Errorf("catalog must be an object")
+		}
+		out[prefix] = fmt.Sprint(v)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "locales")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"en.json":    `{"errors": {"1001": "terms not accepted"}, "greeting": "hello"}`,
+		"fr.yaml":    "# comment\nerrors:\n  1001: conditions non acceptées\n",
+		"de-AT.yml":  "greeting: servus\n",
+		"README.txt": "ignored",
+	}
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	c, err := LoadDir(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"de-AT", "en", "fr"}, c.Locales())
+
+	msg, ok := c.Lookup([]string{"fr", "en"}, "errors.1001")
+	assert.True(t, ok)
+	assert.Equal(t, "conditions non acceptées", msg)
+
+	msg, ok = c.Lookup([]string{"fr", "en"}, "greeting")
+	assert.True(t, ok)
+	assert.Equal(t, "hello", msg)
+
+	msg, ok = c.Lookup(Fallbacks("de_at", "en"), "greeting")
+	assert.True(t, ok)
+	assert.Equal(t, "servus", msg)
+
+	_, ok = c.Lookup([]string{"fr"}, "missing")
+	assert.False(t, ok)
+}
+
+func TestLoadDir_Invalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "locales")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "en.json"), []byte(`{"list": ["a"]}`), 0644))
+
+	_, err = LoadDir(dir)
+	assert.Error(t, err)
+
+	_, err = LoadDir(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
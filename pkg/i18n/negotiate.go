@@ -0,0 +1,139 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Normalize returns canonical form of language tag, e.g. `en-US` for `en_us`
+func Normalize(tag string) string {
+	parts := strings.Split(strings.Replace(strings.TrimSpace(tag), "_", "-", -1), "-")
+
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 2:
+			// region subtag
+			parts[i] = strings.ToUpper(parts[i])
+		case 4:
+			// script subtag
+			parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:])
+		default:
+			parts[i] = strings.ToLower(parts[i])
+		}
+	}
+
+	return strings.Join(parts, "-")
+}
+
+// ParseAcceptLanguage returns language tags listed in `Accept-Language` header
+// ordered by quality value, tags with equal quality keep header order
+//
+// wildcard and tags with zero quality are omitted
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+
+		tag := strings.TrimSpace(params[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, p := range params[1:] {
+			p = strings.TrimSpace(p)
+			if !strings.HasPrefix(p, "q=") {
+				continue
+			}
+			if v, err := strconv.ParseFloat(p[2:], 64); err == nil {
+				q = v
+			}
+		}
+
+		if q > 0 {
+			tags = append(tags, weighted{Normalize(tag), q})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	result := make([]string, 0, len(tags))
+	for _, t := range tags {
+		result = append(result, t.tag)
+	}
+	return result
+}
+
+// Fallbacks returns fallback chain for tag, from most to least specific,
+// ending with defaultLang, e.g. `de-AT`, `de`, `en` for `de-AT`
+func Fallbacks(tag string, defaultLang string) []string {
+	var chain []string
+
+	add := func(t string) {
+		for _, c := range chain {
+			if c == t {
+				return
+			}
+		}
+		chain = append(chain, t)
+	}
+
+	if tag != "" {
+		parts := strings.Split(Normalize(tag), "-")
+		for i := len(parts); i > 0; i-- {
+			add(strings.Join(parts[:i], "-"))
+		}
+	}
+
+	if defaultLang != "" {
+		add(Normalize(defaultLang))
+	}
+
+	return chain
+}
+
+// Negotiate returns supported locale best matching preferences,
+// preferences are language tags or `Accept-Language` header values in priority order
+//
+// fallbacks of each preference are tried before next preference, then
+// supported locales sharing base language, e.g. `en-GB` satisfies `en-US`.
+// defaultLang is returned when nothing matches
+func Negotiate(supported []string, defaultLang string, preferences ...string) string {
+	available := map[string]bool{}
+	for _, s := range supported {
+		available[Normalize(s)] = true
+	}
+
+	var tags []string
+	for _, p := range preferences {
+		tags = append(tags, ParseAcceptLanguage(p)...)
+	}
+
+	for _, tag := range tags {
+		for _, candidate := range Fallbacks(tag, "") {
+			if available[candidate] {
+				return candidate
+			}
+		}
+	}
+
+	for _, tag := range tags {
+		base := Fallbacks(tag, "")
+		for _, s := range supported {
+			if strings.HasPrefix(Normalize(s), base[len(base)-1]+"-") {
+				return Normalize(s)
+			}
+		}
+	}
+
+	return Normalize(defaultLang)
+}
@@ -0,0 +1,66 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"en":         "en",
+		"EN_us":      "en-US",
+		" de-at ":    "de-AT",
+		"zh-hant-tw": "zh-Hant-TW",
+	}
+
+	for tag, expected := range cases {
+		assert.Equal(t, expected, Normalize(tag), "unexpected normalized tag for `%s`", tag)
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	cases := []struct {
+		header   string
+		expected []string
+	}{
+		{"", []string{}},
+		{"fr", []string{"fr"}},
+		{"de;q=0.5, fr-CA, en;q=0.8", []string{"fr-CA", "en", "de"}},
+		{"en-us, *;q=0.1, de;q=0", []string{"en-US"}},
+		{"fr;q=0.7, de;q=0.7", []string{"fr", "de"}},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, ParseAcceptLanguage(c.header), "unexpected tags for `%s`", c.header)
+	}
+}
+
+func TestFallbacks(t *testing.T) {
+	assert.Equal(t, []string{"de-AT", "de", "en"}, Fallbacks("de-at", "en"))
+	assert.Equal(t, []string{"en-US", "en"}, Fallbacks("en-US", "en"))
+	assert.Equal(t, []string{"en"}, Fallbacks("", "en"))
+	assert.Equal(t, []string{"fr"}, Fallbacks("fr", ""))
+}
+
+func TestNegotiate(t *testing.T) {
+	supported := []string{"en", "fr", "pt-BR"}
+
+	cases := []struct {
+		preferences []string
+		expected    string
+	}{
+		{nil, "en"},
+		{[]string{"", ""}, "en"},
+		{[]string{"fr"}, "fr"},
+		{[]string{"", "fr-CA,en;q=0.5"}, "fr"},
+		{[]string{"de", "fr;q=0.4, en;q=0.8"}, "en"},
+		{[]string{"fr", "en"}, "fr"},
+		{[]string{"pt"}, "pt-BR"},
+		{[]string{"ja"}, "en"},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, Negotiate(supported, "en", c.preferences...), "unexpected locale for %v", c.preferences)
+	}
+}
@@ -0,0 +1,72 @@
+package i18n
+
+import (
+	"fmt"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/fr"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	fr_translations "github.com/go-playground/validator/v10/translations/fr"
+)
+
+// validationLocale pairs locale rules with validator default translations
+type validationLocale struct {
+	locale   func() locales.Translator
+	register func(*validator.Validate, ut.Translator) error
+}
+
+// validationLocales lists locales validator messages can be translated to
+var validationLocales = map[string]validationLocale{
+	"en": {en.New, en_translations.RegisterDefaultTranslations},
+	"fr": {fr.New, fr_translations.RegisterDefaultTranslations},
+}
+
+// ValidationTranslator translates validator field errors to human readable messages
+type ValidationTranslator struct {
+	translators map[string]ut.Translator
+}
+
+// NewValidationTranslator registers default translations of given locales to v
+//
+// translations are bound to validator instance, only field errors
+// produced by v can be translated
+func NewValidationTranslator(v *validator.Validate, locales ...string) (*ValidationTranslator, error) {
+	t := &ValidationTranslator{translators: map[string]ut.Translator{}}
+
+	for _, l := range locales {
+		l = Normalize(l)
+
+		vl, ok := validationLocales[l]
+		if !ok {
+			return nil, fmt.Errorf("validation messages are not available for locale `%s`", l)
+		}
+
+		trans, _ := ut.New(vl.locale()).GetTranslator(vl.locale().Locale())
+		if err := vl.register(v, trans); err != nil {
+			return nil, err
+		}
+
+		t.translators[l] = trans
+	}
+
+	return t, nil
+}
+
+// Translate returns message for field error in first locale of chain which has translations
+func (t *ValidationTranslator) Translate(chain []string, fe validator.FieldError) (string, bool) {
+	for _, l := range chain {
+		if trans, ok := t.translators[Normalize(l)]; ok {
+			return fe.Translate(trans), true
+		}
+	}
+	return "", false
+}
+
+// HasValidationLocale returns true if validator messages can be translated to locale
+func HasValidationLocale(locale string) bool {
+	_, ok := validationLocales[Normalize(locale)]
+	return ok
+}
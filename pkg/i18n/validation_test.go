@@ -0,0 +1,41 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationTranslator(t *testing.T) {
+	v := validator.New()
+
+	translator, err := NewValidationTranslator(v, "en", "fr")
+	require.NoError(t, err)
+
+	err = v.Struct(struct {
+		Email string `validate:"required"`
+	}{})
+	require.Error(t, err)
+	fe := err.(validator.ValidationErrors)[0]
+
+	msg, ok := translator.Translate([]string{"en"}, fe)
+	assert.True(t, ok)
+	assert.Equal(t, "Email is a required field", msg)
+
+	msg, ok = translator.Translate(Fallbacks("fr-CA", "en"), fe)
+	assert.True(t, ok)
+	assert.Equal(t, "Email est un champ obligatoire", msg)
+
+	_, ok = translator.Translate([]string{"de"}, fe)
+	assert.False(t, ok)
+}
+
+func TestValidationTranslator_UnsupportedLocale(t *testing.T) {
+	_, err := NewValidationTranslator(validator.New(), "de")
+	assert.Error(t, err)
+
+	assert.True(t, HasValidationLocale("fr"))
+	assert.False(t, HasValidationLocale("de"))
+}
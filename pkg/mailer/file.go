@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// unsafeFileChars matches characters not allowed in message file names
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9@._-]+`)
+
+// FileMailer writes messages as .eml files to a directory
+//
+// it is meant for local development where messages can be opened by any mail client
+type FileMailer struct {
+	Dir string
+}
+
+// NewFileMailer creates FileMailer instance
+func NewFileMailer(dir string) *FileMailer {
+	return &FileMailer{Dir: dir}
+}
+
+// Send writes message to file named after send time and recipient
+func (m *FileMailer) Send(msg Message) error {
+	if err := os.MkdirAll(m.Dir, 0755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s_%s.eml", time.Now().Format("20060102150405.000000000"), unsafeFileChars.ReplaceAllString(msg.To, "_"))
+
+	return ioutil.WriteFile(filepath.Join(m.Dir, name), msg.Bytes(), 0644)
+}
@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Message is plain text email message
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Body    string
+}
+
+// Bytes returns RFC 5322 formatted message
+func (m Message) Bytes() []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "From: %s\r\n", m.From)
+	fmt.Fprintf(&b, "To: %s\r\n", m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", m.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(m.Body)
+
+	return []byte(b.String())
+}
+
+// Mailer sends email messages
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// New creates Mailer implementation for given driver name
+//
+// supported drivers are `smtp`, `file` and `memory`
+func New(driver string, host string, port int, username string, password string, dir string) (Mailer, error) {
+	switch driver {
+	case "smtp":
+		return NewSMTPMailer(host, port, username, password), nil
+	case "file":
+		return NewFileMailer(dir), nil
+	case "", "memory":
+		return NewMemoryMailer(), nil
+	}
+
+	return nil, fmt.Errorf("mailer: driver `%s` is not supported", driver)
+}
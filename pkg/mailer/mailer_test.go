@@ -0,0 +1,48 @@
+package mailer
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryMailer(t *testing.T) {
+	m := NewMemoryMailer()
+
+	_, ok := m.Last()
+	assert.False(t, ok, "expected no messages to be sent")
+
+	require.NoError(t, m.Send(Message{To: "first@example.com"}))
+	require.NoError(t, m.Send(Message{To: "second@example.com"}))
+
+	last, ok := m.Last()
+	assert.True(t, ok, "expected last message to exist")
+	assert.Equal(t, "second@example.com", last.To, "expected last message recipient to be second@example.com, got %s", last.To)
+	assert.Len(t, m.Messages(), 2, "expected 2 messages to be sent")
+
+	m.Reset()
+	assert.Len(t, m.Messages(), 0, "expected messages to be removed")
+}
+
+func TestFileMailer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mailer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	m := NewFileMailer(dir)
+	require.NoError(t, m.Send(Message{From: "from@example.com", To: "to@example.com", Subject: "Hello", Body: "Body"}))
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1, "expected single message file")
+	assert.True(t, strings.HasSuffix(files[0].Name(), "_to@example.com.eml"), "unexpected file name %s", files[0].Name())
+
+	content, err := ioutil.ReadFile(dir + "/" + files[0].Name())
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Subject: Hello\r\n")
+	assert.True(t, strings.HasSuffix(string(content), "\r\n\r\nBody"), "expected body after headers")
+}
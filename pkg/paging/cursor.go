@@ -0,0 +1,93 @@
+package paging
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned when cursor is malformed, tampered with or issued for different sort
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorTimeFormat is format used for time values stored in cursor
+const cursorTimeFormat = "2006-01-02 15:04:05.999999"
+
+// Cursor is position in keyset paginated result set
+type Cursor struct {
+	// Sort is sort expression cursor was issued for
+	Sort string `json:"s"`
+	// Values are sort key values of the row followed by its ID
+	Values []interface{} `json:"v"`
+	// Backward is true for cursors pointing to previous page
+	Backward bool `json:"b,omitempty"`
+}
+
+// CursorCodec encodes cursors to opaque signed strings and decodes them back
+type CursorCodec struct {
+	key []byte
+}
+
+// NewCursorCodec creates CursorCodec signing cursors with given key
+func NewCursorCodec(key string) *CursorCodec {
+	return &CursorCodec{key: []byte(key)}
+}
+
+// Encode returns cursor as base64 encoded payload followed by its HMAC-SHA256 signature
+func (c *CursorCodec) Encode(cursor *Cursor) (string, error) {
+	values := make([]interface{}, len(cursor.Values))
+	for i, v := range cursor.Values {
+		if t, ok := v.(time.Time); ok {
+			v = t.Format(cursorTimeFormat)
+		}
+		values[i] = v
+	}
+
+	payload, err := json.Marshal(&Cursor{Sort: cursor.Sort, Values: values, Backward: cursor.Backward})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(c.sign(payload)), nil
+}
+
+// Decode verifies cursor signature and returns decoded cursor
+//
+// numeric values are decoded as json.Number and time values as strings,
+// both of which can be bound to SQL queries directly
+func (c *CursorCodec) Decode(value string) (*Cursor, error) {
+	parts := strings.Split(value, ".")
+	if len(parts) != 2 {
+		return nil, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, c.sign(payload)) {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := new(Cursor)
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// sign returns HMAC-SHA256 signature of payload
+func (c *CursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,45 @@
+package paging
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorCodec(t *testing.T) {
+	codec := NewCursorCodec("secret")
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+
+	encoded, err := codec.Encode(&Cursor{Sort: "-created_at", Values: []interface{}{createdAt, uint64(42)}, Backward: true})
+	assert.Nil(t, err, "unexpected encode error %v", err)
+
+	cursor, err := codec.Decode(encoded)
+	assert.Nil(t, err, "unexpected decode error %v", err)
+	assert.Equal(t, "-created_at", cursor.Sort)
+	assert.Equal(t, []interface{}{"2024-01-02 03:04:05.000006", json.Number("42")}, cursor.Values)
+	assert.True(t, cursor.Backward, "expected backward cursor")
+}
+
+func TestCursorCodecRejectsTampering(t *testing.T) {
+	codec := NewCursorCodec("secret")
+
+	encoded, _ := codec.Encode(&Cursor{Sort: "id", Values: []interface{}{1}})
+	parts := strings.Split(encoded, ".")
+
+	forged, _ := NewCursorCodec("other").Encode(&Cursor{Sort: "id", Values: []interface{}{1000}})
+
+	for _, value := range []string{
+		"",
+		"garbage",
+		parts[0],
+		parts[0] + ".",
+		strings.Split(forged, ".")[0] + "." + parts[1],
+		forged,
+	} {
+		_, err := codec.Decode(value)
+		assert.Equal(t, ErrInvalidCursor, err, "expected `%s` to be rejected", value)
+	}
+}
@@ -0,0 +1,138 @@
+package paging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FieldWhitelist maps API field names which can be requested in sparse fieldset to database columns
+type FieldWhitelist map[string]string
+
+// Fields returns alphabetically sorted API field names allowed in sparse fieldset
+func (w FieldWhitelist) Fields() []string {
+	fields := make([]string, 0, len(w))
+	for field := range w {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Fields is sparse fieldset, list of API fields requested in response
+//
+// empty fieldset means all fields are requested
+type Fields []string
+
+// FieldsError is returned when sparse fieldset is not valid for given whitelist
+type FieldsError struct {
+	// Field is invalid field
+	Field string
+	// Allowed are API field names allowed in sparse fieldset
+	Allowed []string
+}
+
+// Error implements error interface
+func (e *FieldsError) Error() string {
+	return fmt.Sprintf("invalid field `%s`, allowed fields are: %s", e.Field, strings.Join(e.Allowed, ", "))
+}
+
+// ParseFields parses comma separated sparse fieldset, e.g. `id,email,first_name`
+//
+// every field has to be present in whitelist, repeated fields are ignored
+// and empty value results in empty fieldset
+func ParseFields(value string, whitelist FieldWhitelist) (Fields, error) {
+	fields := make(Fields, 0)
+	if strings.TrimSpace(value) == "" {
+		return fields, nil
+	}
+
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		field := strings.TrimSpace(part)
+
+		if _, ok := whitelist[field]; !ok {
+			return nil, &FieldsError{Field: field, Allowed: whitelist.Fields()}
+		}
+		if seen[field] {
+			continue
+		}
+
+		seen[field] = true
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// Columns returns database columns which have to be selected for fieldset
+//
+// required fields, e.g. primary key or cursor sort fields, are selected even when
+// they are not requested. nil is returned for empty fieldset, meaning all columns.
+func (f Fields) Columns(whitelist FieldWhitelist, required ...string) []string {
+	if len(f) == 0 {
+		return nil
+	}
+
+	columns := make([]string, 0, len(f)+len(required))
+	seen := make(map[string]bool)
+
+	for _, field := range append(append([]string{}, required...), f...) {
+		column, ok := whitelist[field]
+		if !ok || seen[column] {
+			continue
+		}
+		seen[column] = true
+		columns = append(columns, column)
+	}
+
+	return columns
+}
+
+// Project returns JSON representation of v narrowed to fieldset
+//
+// objects keep only fieldset keys, and arrays are projected element by element.
+// v is returned unchanged for empty fieldset.
+func (f Fields) Project(v interface{}) (interface{}, error) {
+	if len(f) == 0 {
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return f.project(value), nil
+}
+
+// project narrows decoded JSON value to fieldset
+func (f Fields) project(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		projected := make(map[string]interface{}, len(f))
+		for _, field := range f {
+			if fv, ok := v[field]; ok {
+				projected[field] = fv
+			}
+		}
+		return projected
+	case []interface{}:
+		for i := range v {
+			v[i] = f.project(v[i])
+		}
+		return v
+	}
+
+	return value
+}
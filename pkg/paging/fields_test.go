@@ -0,0 +1,76 @@
+package paging
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFields = FieldWhitelist{
+	"id":         "id",
+	"email":      "email",
+	"first_name": "first_name",
+	"created_at": "created_at",
+}
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields(" email, id,email ", testFields)
+
+	require.NoError(t, err)
+	assert.Equal(t, Fields{"email", "id"}, fields)
+}
+
+func TestParseFields_Empty(t *testing.T) {
+	fields, err := ParseFields("", testFields)
+
+	require.NoError(t, err)
+	assert.Empty(t, fields)
+	assert.Nil(t, fields.Columns(testFields, "id"), "expected empty fieldset to select all columns")
+}
+
+func TestParseFields_Invalid(t *testing.T) {
+	_, err := ParseFields("id,password_hash", testFields)
+
+	require.Error(t, err)
+	assert.IsType(t, &FieldsError{}, err)
+	assert.Equal(t, "invalid field `password_hash`, allowed fields are: created_at, email, first_name, id", err.Error())
+}
+
+func TestFields_Columns(t *testing.T) {
+	fields := Fields{"email", "first_name"}
+
+	assert.Equal(t, []string{"id", "created_at", "email", "first_name"}, fields.Columns(testFields, "id", "created_at", "id"))
+}
+
+func TestFields_Project(t *testing.T) {
+	type user struct {
+		ID        uint64 `json:"id"`
+		Email     string `json:"email"`
+		FirstName string `json:"first_name"`
+	}
+
+	fields := Fields{"id", "email"}
+
+	single, err := fields.Project(&user{ID: 1, Email: "sedin@mop.ba", FirstName: "Sedin"})
+	require.NoError(t, err)
+
+	data, _ := json.Marshal(single)
+	assert.JSONEq(t, `{"id":1,"email":"sedin@mop.ba"}`, string(data))
+
+	list, err := fields.Project([]*user{{ID: 1, Email: "sedin@mop.ba"}, {ID: 2, Email: "amer@mop.ba"}})
+	require.NoError(t, err)
+
+	data, _ = json.Marshal(list)
+	assert.JSONEq(t, `[{"id":1,"email":"sedin@mop.ba"},{"id":2,"email":"amer@mop.ba"}]`, string(data))
+}
+
+func TestFields_Project_Empty(t *testing.T) {
+	value := map[string]int{"id": 1}
+
+	projected, err := Fields{}.Project(value)
+
+	require.NoError(t, err)
+	assert.Equal(t, value, projected)
+}
@@ -0,0 +1,313 @@
+package paging
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterOperator is comparison operator of filter condition
+type FilterOperator string
+
+const (
+	// FilterEq matches values equal to filter value
+	FilterEq FilterOperator = "eq"
+	// FilterNe matches values not equal to filter value
+	FilterNe FilterOperator = "ne"
+	// FilterIn matches values equal to any of comma separated filter values
+	FilterIn FilterOperator = "in"
+	// FilterLike matches values containing filter value, `*` in filter value matches any text
+	FilterLike FilterOperator = "like"
+	// FilterGte matches values greater than or equal to filter value
+	FilterGte FilterOperator = "gte"
+	// FilterLte matches values less than or equal to filter value
+	FilterLte FilterOperator = "lte"
+	// FilterIsNull matches NULL values when filter value is true and other values when it is false
+	FilterIsNull FilterOperator = "is-null"
+)
+
+// FilterType is type of filtered field, it defines how filter values are parsed and which operators are allowed
+type FilterType int
+
+const (
+	// FilterString is text field
+	FilterString FilterType = iota
+	// FilterInt is integer field
+	FilterInt
+	// FilterBool is boolean field
+	FilterBool
+	// FilterTime is date or timestamp field, values are dates `2006-01-02` or RFC 3339 timestamps
+	FilterTime
+)
+
+// filterTypeNames are names of field types used in error messages
+var filterTypeNames = map[FilterType]string{
+	FilterString: "text",
+	FilterInt:    "integer",
+	FilterBool:   "boolean",
+	FilterTime:   "date or RFC 3339 timestamp",
+}
+
+// filterOperators are operators allowed for every field type
+var filterOperators = map[FilterType][]FilterOperator{
+	FilterString: {FilterEq, FilterNe, FilterIn, FilterLike, FilterIsNull},
+	FilterInt:    {FilterEq, FilterNe, FilterIn, FilterGte, FilterLte, FilterIsNull},
+	FilterBool:   {FilterEq, FilterNe, FilterIsNull},
+	FilterTime:   {FilterEq, FilterNe, FilterGte, FilterLte, FilterIsNull},
+}
+
+// FilterField describes field which can be used for filtering
+type FilterField struct {
+	// Column is database column of the field
+	Column string
+	// Type is field type
+	Type FilterType
+}
+
+// FilterWhitelist maps API field names which can be used for filtering to their columns and types
+type FilterWhitelist map[string]FilterField
+
+// Fields returns alphabetically sorted API field names allowed for filtering
+func (w FilterWhitelist) Fields() []string {
+	fields := make([]string, 0, len(w))
+	for field := range w {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Only returns whitelist restricted to given API field names
+func (w FilterWhitelist) Only(fields ...string) FilterWhitelist {
+	only := make(FilterWhitelist, len(fields))
+	for _, field := range fields {
+		if f, ok := w[field]; ok {
+			only[field] = f
+		}
+	}
+	return only
+}
+
+// FilterCondition is single parsed filter condition, e.g. `filter[created_at][gte]=2024-01-01`
+type FilterCondition struct {
+	Field    string         `json:"field"`
+	Operator FilterOperator `json:"operator"`
+	Value    string         `json:"value"`
+}
+
+// Filter is parsed filter expression, conjunction of its conditions
+type Filter []FilterCondition
+
+// Predicate is filter condition bound to column with typed value
+type Predicate struct {
+	Column   string
+	Operator FilterOperator
+	// Value is typed value, slice of typed values for FilterIn and bool for FilterIsNull
+	Value interface{}
+}
+
+// FilterError is returned when filter condition is not valid for given whitelist
+type FilterError struct {
+	// Condition is invalid condition
+	Condition FilterCondition
+	// Reason describes why condition is invalid
+	Reason string
+}
+
+// Error implements error interface
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("invalid filter `filter[%s][%s]`: %s", e.Condition.Field, e.Condition.Operator, e.Reason)
+}
+
+// ParseFilter parses `filter[field]=value` and `filter[field][operator]=value` query parameters
+//
+// condition without operator uses FilterEq, conditions are ordered by field and operator.
+// Fields, operators and values are validated when filter is bound to whitelist.
+func ParseFilter(params url.Values) Filter {
+	filter := make(Filter, 0)
+
+	for key := range params {
+		if !strings.HasPrefix(key, "filter[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+
+		parts := strings.Split(key[len("filter["):len(key)-1], "][")
+		condition := FilterCondition{Field: parts[0], Operator: FilterEq, Value: params.Get(key)}
+		if len(parts) > 1 {
+			condition.Operator = FilterOperator(strings.Join(parts[1:], "]["))
+		}
+
+		filter = append(filter, condition)
+	}
+
+	sort.Slice(filter, func(i, j int) bool {
+		if filter[i].Field != filter[j].Field {
+			return filter[i].Field < filter[j].Field
+		}
+		return filter[i].Operator < filter[j].Operator
+	})
+
+	return filter
+}
+
+// Bind validates filter against whitelist and returns predicates with typed values
+//
+// *FilterError is returned for unknown field, operator not allowed for field type or value not matching field type
+func (f Filter) Bind(whitelist FilterWhitelist) ([]Predicate, error) {
+	predicates := make([]Predicate, 0, len(f))
+
+	for _, condition := range f {
+		field, ok := whitelist[condition.Field]
+		if !ok {
+			return nil, &FilterError{
+				Condition: condition,
+				Reason:    "allowed fields are: " + strings.Join(whitelist.Fields(), ", "),
+			}
+		}
+
+		if !allowsOperator(field.Type, condition.Operator) {
+			return nil, &FilterError{
+				Condition: condition,
+				Reason:    "allowed operators are: " + joinOperators(filterOperators[field.Type]),
+			}
+		}
+
+		value, err := bindValue(field.Type, condition)
+		if err != nil {
+			return nil, &FilterError{
+				Condition: condition,
+				Reason:    fmt.Sprintf("value `%s` is not valid %s", condition.Value, filterTypeNames[field.Type]),
+			}
+		}
+
+		predicates = append(predicates, Predicate{Column: field.Column, Operator: condition.Operator, Value: value})
+	}
+
+	return predicates, nil
+}
+
+// Where returns WHERE clause and its arguments for filter bound to whitelist
+//
+// empty clause is returned for empty filter
+func (f Filter) Where(whitelist FilterWhitelist) (string, []interface{}, error) {
+	predicates, err := f.Bind(whitelist)
+	if err != nil {
+		return "", nil, err
+	}
+
+	clauses := make([]string, 0, len(predicates))
+	args := make([]interface{}, 0, len(predicates))
+
+	for _, p := range predicates {
+		switch p.Operator {
+		case FilterEq:
+			clauses = append(clauses, p.Column+" = ?")
+		case FilterNe:
+			clauses = append(clauses, p.Column+" <> ?")
+		case FilterIn:
+			clauses = append(clauses, p.Column+" IN (?)")
+		case FilterLike:
+			clauses = append(clauses, LikeClause(p.Column))
+		case FilterGte:
+			clauses = append(clauses, p.Column+" >= ?")
+		case FilterLte:
+			clauses = append(clauses, p.Column+" <= ?")
+		case FilterIsNull:
+			if p.Value.(bool) {
+				clauses = append(clauses, p.Column+" IS NULL")
+			} else {
+				clauses = append(clauses, p.Column+" IS NOT NULL")
+			}
+			continue
+		}
+		args = append(args, p.Value)
+	}
+
+	return strings.Join(clauses, " AND "), args, nil
+}
+
+// bindValue parses condition value according to field type and operator
+func bindValue(fieldType FilterType, condition FilterCondition) (interface{}, error) {
+	switch condition.Operator {
+	case FilterIsNull:
+		return strconv.ParseBool(condition.Value)
+	case FilterLike:
+		return LikePattern(condition.Value), nil
+	case FilterIn:
+		values := make([]interface{}, 0)
+		for _, v := range strings.Split(condition.Value, ",") {
+			value, err := parseValue(fieldType, strings.TrimSpace(v))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	return parseValue(fieldType, condition.Value)
+}
+
+// parseValue parses single filter value according to field type
+func parseValue(fieldType FilterType, value string) (interface{}, error) {
+	switch fieldType {
+	case FilterInt:
+		return strconv.ParseInt(value, 10, 64)
+	case FilterBool:
+		return strconv.ParseBool(value)
+	case FilterTime:
+		if t, err := time.Parse("2006-01-02", value); err == nil {
+			return t, nil
+		}
+		return time.Parse(time.RFC3339, value)
+	}
+	return value, nil
+}
+
+// likeEscape is escape character of LIKE patterns
+//
+// backslash is not used, it has to be written differently in MySQL and in
+// PostgreSQL or SQLite string literals, while `!` is written the same in all of them
+const likeEscape = "!"
+
+// likeEscaper escapes escape character and LIKE special characters
+var likeEscaper = strings.NewReplacer(likeEscape, likeEscape+likeEscape, `%`, likeEscape+`%`, `_`, likeEscape+`_`)
+
+// LikeClause returns LIKE condition of column with escape character of LikePattern
+func LikeClause(column string) string {
+	return column + " LIKE ? ESCAPE '" + likeEscape + "'"
+}
+
+// LikePattern returns LIKE pattern matching value literally, with `*` replaced by `%`
+// and value wrapped in `%` when it does not contain wildcard
+//
+// pattern has to be used with LikeClause condition
+func LikePattern(value string) string {
+	value = likeEscaper.Replace(value)
+	if !strings.Contains(value, "*") {
+		return "%" + value + "%"
+	}
+	return strings.Replace(value, "*", "%", -1)
+}
+
+// allowsOperator reports whether operator is allowed for field type
+func allowsOperator(fieldType FilterType, operator FilterOperator) bool {
+	for _, allowed := range filterOperators[fieldType] {
+		if allowed == operator {
+			return true
+		}
+	}
+	return false
+}
+
+// joinOperators returns comma separated operators
+func joinOperators(operators []FilterOperator) string {
+	names := make([]string, len(operators))
+	for i, operator := range operators {
+		names[i] = string(operator)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,91 @@
+package paging
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testFilterFields = FilterWhitelist{
+	"country":    {Column: "country", Type: FilterString},
+	"is_active":  {Column: "is_active", Type: FilterBool},
+	"id":         {Column: "id", Type: FilterInt},
+	"created_at": {Column: "users.created_at", Type: FilterTime},
+	"deleted_at": {Column: "deleted_at", Type: FilterTime},
+}
+
+func TestParseFilter(t *testing.T) {
+	params, _ := url.ParseQuery("filter[is_active]=true&filter[created_at][gte]=2024-01-01&filter[country]=DE&filter=legacy&page=2")
+
+	filter := ParseFilter(params)
+
+	assert.Equal(t, Filter{
+		{Field: "country", Operator: FilterEq, Value: "DE"},
+		{Field: "created_at", Operator: FilterGte, Value: "2024-01-01"},
+		{Field: "is_active", Operator: FilterEq, Value: "true"},
+	}, filter)
+}
+
+func TestFilterWhere(t *testing.T) {
+	cases := []struct {
+		query  string
+		where  string
+		args   []interface{}
+		hasErr bool
+	}{
+		{"", "", []interface{}{}, false},
+		{
+			"filter[country]=DE&filter[created_at][gte]=2024-01-01&filter[is_active]=true",
+			"country = ? AND users.created_at >= ? AND is_active = ?",
+			[]interface{}{"DE", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), true},
+			false,
+		},
+		{"filter[id][in]=1, 2,3", "id IN (?)", []interface{}{[]interface{}{int64(1), int64(2), int64(3)}}, false},
+		{"filter[country][ne]=DE", "country <> ?", []interface{}{"DE"}, false},
+		{"filter[country][like]=D_%25!", "country LIKE ? ESCAPE '!'", []interface{}{"%D!_!%!!%"}, false},
+		{"filter[country][like]=D*", "country LIKE ? ESCAPE '!'", []interface{}{"D%"}, false},
+		{"filter[id][lte]=10", "id <= ?", []interface{}{int64(10)}, false},
+		{"filter[deleted_at][is-null]=true", "deleted_at IS NULL", []interface{}{}, false},
+		{"filter[deleted_at][is-null]=false", "deleted_at IS NOT NULL", []interface{}{}, false},
+		{"filter[created_at][lte]=2024-01-01T10:00:00Z", "users.created_at <= ?", []interface{}{time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}, false},
+		{"filter[password_hash]=x", "", nil, true},
+		{"filter[is_active][gte]=true", "", nil, true},
+		{"filter[country][regexp]=D", "", nil, true},
+		{"filter[id]=one", "", nil, true},
+		{"filter[id][in]=1,x", "", nil, true},
+		{"filter[created_at][gte]=yesterday", "", nil, true},
+		{"filter[deleted_at][is-null]=maybe", "", nil, true},
+	}
+
+	for _, c := range cases {
+		params, _ := url.ParseQuery(c.query)
+
+		where, args, err := ParseFilter(params).Where(testFilterFields)
+		if c.hasErr {
+			assert.IsType(t, &FilterError{}, err, "expected filter error for `%s`", c.query)
+			continue
+		}
+
+		assert.Nil(t, err, "unexpected error for `%s`", c.query)
+		assert.Equal(t, c.where, where, "unexpected where clause for `%s`", c.query)
+		assert.Equal(t, c.args, args, "unexpected arguments for `%s`", c.query)
+	}
+}
+
+func TestFilterErrorListsAllowedFields(t *testing.T) {
+	params, _ := url.ParseQuery("filter[email]=x")
+
+	_, _, err := ParseFilter(params).Where(testFilterFields)
+
+	assert.EqualError(t, err, "invalid filter `filter[email][eq]`: allowed fields are: country, created_at, deleted_at, id, is_active")
+}
+
+func TestFilterWhitelistOnly(t *testing.T) {
+	params, _ := url.ParseQuery("filter[deleted_at][is-null]=false")
+
+	_, _, err := ParseFilter(params).Where(testFilterFields.Only("country", "id", "unknown"))
+
+	assert.EqualError(t, err, "invalid filter `filter[deleted_at][is-null]`: allowed fields are: country, id")
+}
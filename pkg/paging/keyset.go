@@ -0,0 +1,168 @@
+package paging
+
+import "strings"
+
+// Keyset is cursor pagination query built from paginator sort and cursor
+//
+// rows are ordered by sort fields followed by ID as tiebreaker, and every page
+// continues after (or before, for backward cursors) the row cursor points to,
+// so database never has to skip rows with OFFSET or count all rows.
+type Keyset struct {
+	// Fields are sort fields followed by ID tiebreaker
+	Fields []SortField
+
+	sort   string
+	cursor *Cursor
+	limit  int
+	codec  *CursorCodec
+}
+
+// Keyset returns cursor pagination query for paginator
+//
+// *SortError is returned for sort not allowed by whitelist, and ErrInvalidCursor
+// when cursor is not valid or was issued for different sort
+func (p *Paginator) Keyset(whitelist SortWhitelist, defaultSort string, idField string, codec *CursorCodec) (*Keyset, error) {
+	if _, err := p.Order(whitelist, defaultSort); err != nil {
+		return nil, err
+	}
+
+	fields, _ := ParseSort(p.Sort, whitelist)
+
+	// ID is appended as tiebreaker so order is total
+	tiebreaker := true
+	for _, field := range fields {
+		if field.Field == idField {
+			tiebreaker = false
+		}
+	}
+	if tiebreaker {
+		column, ok := whitelist[idField]
+		if !ok {
+			column = idField
+		}
+		fields = append(fields, SortField{Field: idField, Column: column})
+	}
+
+	ks := &Keyset{
+		Fields: fields,
+		sort:   p.Sort,
+		limit:  p.PerPage,
+		codec:  codec,
+	}
+
+	if p.Cursor != "" {
+		cursor, err := codec.Decode(p.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.Sort != p.Sort || len(cursor.Values) != len(fields) {
+			return nil, ErrInvalidCursor
+		}
+		ks.cursor = cursor
+	}
+
+	return ks, nil
+}
+
+// Backward reports whether keyset fetches page before cursor
+//
+// rows of backward page are fetched in reversed order and have to be reversed before rendering
+func (ks *Keyset) Backward() bool {
+	return ks.cursor != nil && ks.cursor.Backward
+}
+
+// Limit returns number of rows to fetch, one more than page size to detect further pages
+func (ks *Keyset) Limit() int {
+	return ks.limit + 1
+}
+
+// Order returns ORDER BY clause, reversed for backward pages
+func (ks *Keyset) Order() string {
+	columns := make([]string, len(ks.Fields))
+	for i, field := range ks.Fields {
+		direction := "ASC"
+		if field.Desc != ks.Backward() {
+			direction = "DESC"
+		}
+		columns[i] = field.Column + " " + direction
+	}
+	return strings.Join(columns, ", ")
+}
+
+// Condition returns WHERE clause selecting rows after cursor and its arguments
+//
+// for sort `a, b` clause is `(a > ?) OR (a = ? AND b > ?)`, empty clause is returned for first page
+func (ks *Keyset) Condition() (string, []interface{}) {
+	if ks.cursor == nil {
+		return "", nil
+	}
+
+	clauses := make([]string, len(ks.Fields))
+	args := make([]interface{}, 0)
+
+	for i, field := range ks.Fields {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, ks.Fields[j].Column+" = ?")
+			args = append(args, ks.cursor.Values[j])
+		}
+
+		operator := " > ?"
+		if field.Desc != ks.Backward() {
+			operator = " < ?"
+		}
+		parts = append(parts, field.Column+operator)
+		args = append(args, ks.cursor.Values[i])
+
+		clauses[i] = "(" + strings.Join(parts, " AND ") + ")"
+	}
+
+	return strings.Join(clauses, " OR "), args
+}
+
+// SetCursors sets next and previous page cursors on paginator
+//
+// rows is number of rows on the page in display order, more reports whether
+// more rows exist in fetch direction and value returns value of field for row with given index
+func (ks *Keyset) SetCursors(p *Paginator, rows int, more bool, value func(i int, field string) interface{}) error {
+	p.NextCursor = ""
+	p.PrevCursor = ""
+	p.CurrentEntriesSize = rows
+
+	if rows == 0 {
+		return nil
+	}
+
+	hasNext, hasPrev := more, ks.cursor != nil
+	if ks.Backward() {
+		hasNext, hasPrev = true, more
+	}
+
+	var err error
+	if hasNext {
+		if p.NextCursor, err = ks.encode(rows-1, false, value); err != nil {
+			return err
+		}
+	}
+	if hasPrev {
+		if p.PrevCursor, err = ks.encode(0, true, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encode returns cursor pointing to row with given index
+func (ks *Keyset) encode(row int, backward bool, value func(i int, field string) interface{}) (string, error) {
+	cursor := &Cursor{
+		Sort:     ks.sort,
+		Values:   make([]interface{}, len(ks.Fields)),
+		Backward: backward,
+	}
+	for i, field := range ks.Fields {
+		cursor.Values[i] = value(row, field.Field)
+	}
+
+	return ks.codec.Encode(cursor)
+}
@@ -0,0 +1,80 @@
+package paging
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysetFirstPage(t *testing.T) {
+	params, _ := url.ParseQuery("sort=-created_at&per_page=2")
+	paginator := NewPaginatorFromParams(params)
+
+	keyset, err := paginator.Keyset(testSortFields, "id", "id", NewCursorCodec("secret"))
+	assert.Nil(t, err, "unexpected error %v", err)
+
+	condition, _ := keyset.Condition()
+	assert.Equal(t, "", condition, "expected first page without condition")
+	assert.Equal(t, "users.created_at DESC, id ASC", keyset.Order())
+	assert.Equal(t, 3, keyset.Limit())
+}
+
+func TestKeysetCursors(t *testing.T) {
+	codec := NewCursorCodec("secret")
+	rows := []map[string]interface{}{
+		{"last_name": "Doe", "id": uint64(3)},
+		{"last_name": "Roe", "id": uint64(1)},
+	}
+	value := func(i int, field string) interface{} {
+		return rows[i][field]
+	}
+
+	// first page with more rows
+	params, _ := url.ParseQuery("mode=cursor&sort=last_name&per_page=2")
+	paginator := NewPaginatorFromParams(params)
+	assert.Equal(t, ModeCursor, paginator.Mode)
+
+	keyset, _ := paginator.Keyset(testSortFields, "id", "id", codec)
+	assert.Nil(t, keyset.SetCursors(paginator, len(rows), true, value))
+	assert.NotEmpty(t, paginator.NextCursor, "expected next cursor")
+	assert.Empty(t, paginator.PrevCursor, "expected no previous cursor on first page")
+
+	// next page continues after last row
+	params.Set("cursor", paginator.NextCursor)
+	next := NewPaginatorFromParams(params)
+
+	keyset, err := next.Keyset(testSortFields, "id", "id", codec)
+	assert.Nil(t, err, "unexpected error %v", err)
+
+	condition, args := keyset.Condition()
+	assert.Equal(t, "(last_name > ?) OR (last_name = ? AND id > ?)", condition)
+	assert.Equal(t, 3, len(args))
+	assert.False(t, keyset.Backward())
+
+	assert.Nil(t, keyset.SetCursors(next, len(rows), false, value))
+	assert.Empty(t, next.NextCursor, "expected no next cursor on last page")
+	assert.NotEmpty(t, next.PrevCursor, "expected previous cursor")
+
+	// previous page is fetched backwards
+	params.Set("cursor", next.PrevCursor)
+	prev := NewPaginatorFromParams(params)
+
+	keyset, _ = prev.Keyset(testSortFields, "id", "id", codec)
+	condition, _ = keyset.Condition()
+	assert.True(t, keyset.Backward())
+	assert.Equal(t, "(last_name < ?) OR (last_name = ? AND id < ?)", condition)
+	assert.Equal(t, "last_name DESC, id DESC", keyset.Order())
+}
+
+func TestKeysetRejectsCursorForDifferentSort(t *testing.T) {
+	codec := NewCursorCodec("secret")
+	cursor, _ := codec.Encode(&Cursor{Sort: "last_name", Values: []interface{}{"Doe", 3}})
+
+	params := url.Values{"sort": {"-created_at"}, "cursor": {cursor}}
+	paginator := NewPaginatorFromParams(params)
+
+	_, err := paginator.Keyset(testSortFields, "id", "id", codec)
+
+	assert.Equal(t, ErrInvalidCursor, err)
+}
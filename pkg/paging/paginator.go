@@ -1,8 +1,18 @@
 package paging
 
 import (
-	"fmt"
+	"encoding/json"
+	"net/url"
 	"strconv"
+	"strings"
+)
+
+const (
+	// ModePage is pagination mode using page number and LIMIT/OFFSET
+	ModePage = "page"
+
+	// ModeCursor is keyset pagination mode using opaque cursors
+	ModeCursor = "cursor"
 )
 
 var (
@@ -18,7 +28,12 @@ var (
 )
 
 // Paginator is a type used to represent the pagination
+//
+// in cursor mode page number, offset and totals are not used,
+// and next and previous page are addressed with cursors instead
 type Paginator struct {
+	// Mode is pagination mode, ModePage or ModeCursor
+	Mode string `json:"mode"`
 	// Current page you're on
 	Page int `json:"page"`
 	// Number of results you want per page
@@ -31,12 +46,30 @@ type Paginator struct {
 	CurrentEntriesSize int `json:"current_entries_size"`
 	// Total pages
 	TotalPages int `json:"total_pages"`
-	// OrderBy field
+	// Sort expression, e.g. `-created_at,last_name`
+	Sort string `json:"sort"`
+	// OrderBy field, deprecated in favour of Sort
 	OrderBy string `json:"order_by"`
-	// Order Direction
+	// Order Direction, deprecated in favour of Sort
 	OrderDir string `json:"order_dir"`
-	// Filter
+	// Filter is matched literally against first and last name, deprecated in favour of Filters
 	Filter string `json:"filter"`
+	// Filters are structured filter conditions, e.g. `filter[country]=DE`
+	Filters Filter `json:"filters,omitempty"`
+	// Fields is sparse fieldset requested in response, all fields when empty
+	Fields Fields `json:"fields,omitempty"`
+	// WithDeleted includes soft deleted records
+	WithDeleted bool `json:"with_deleted"`
+	// OnlyDeleted returns only soft deleted records
+	OnlyDeleted bool `json:"only_deleted"`
+	// Private allows sorting and filtering on fields which are not public, set for viewers granted access to them
+	Private bool `json:"-"`
+	// Cursor is requested cursor, first page is returned when empty
+	Cursor string `json:"-"`
+	// NextCursor addresses next page in cursor mode, empty on last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	// PrevCursor addresses previous page in cursor mode, empty on first page
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 // PaginationParams is a parameters provider interface to get the pagination params from
@@ -58,7 +91,7 @@ func NewPaginator(page int, perPage int, orderBy, orderDir, filter string) *Pagi
 	if perPage < 1 {
 		perPage = PaginatorPerPageDefault
 	}
-	p := &Paginator{Page: page, PerPage: perPage, OrderBy: orderBy, OrderDir: orderDir, Filter: filter}
+	p := &Paginator{Mode: ModePage, Page: page, PerPage: perPage, OrderBy: orderBy, OrderDir: orderDir, Filter: filter}
 	p.Offset = (page - 1) * p.PerPage
 	return p
 }
@@ -104,18 +137,99 @@ func NewPaginatorFromParams(params PaginationParams) *Paginator {
 		filter = f
 	}
 
-	return NewPaginator(p, pp, orderBy, orderDir, filter)
+	paginator := NewPaginator(p, pp, orderBy, orderDir, filter)
+	paginator.Sort = params.Get("sort")
+
+	// structured filters can be parsed only from query values
+	if values, ok := params.(url.Values); ok {
+		paginator.Filters = ParseFilter(values)
+	}
+
+	// cursor mode is selected explicitly or by sending cursor
+	paginator.Cursor = params.Get("cursor")
+	if params.Get("mode") == ModeCursor || paginator.Cursor != "" {
+		paginator.Mode = ModeCursor
+	}
+
+	// invalid values are treated as false
+	paginator.WithDeleted, _ = strconv.ParseBool(params.Get("with_deleted"))
+	paginator.OnlyDeleted, _ = strconv.ParseBool(params.Get("only_deleted"))
+
+	return paginator
 }
 
-// Order returns ordering string
-func (p *Paginator) Order(defaultOrder string) string {
-	if p.OrderBy == "" {
-		p.OrderBy = defaultOrder
+// Order returns ORDER BY clause for paginator sort expression
+//
+// columns are taken only from whitelist so raw query values never reach SQL.
+// When sort expression is empty, deprecated order_by and order_dir values are used,
+// and when they are empty as well defaultSort is applied.
+// *SortError listing allowed fields is returned for invalid field or direction.
+func (p *Paginator) Order(whitelist SortWhitelist, defaultSort string) (string, error) {
+	value := p.Sort
+	if value == "" && p.OrderBy != "" {
+		switch strings.ToUpper(p.OrderDir) {
+		case "", "ASC":
+			value = p.OrderBy
+		case "DESC":
+			value = "-" + p.OrderBy
+		default:
+			return "", &SortError{Field: p.OrderDir, Allowed: whitelist.Fields()}
+		}
 	}
+	if value == "" {
+		value = defaultSort
+	}
+
+	fields, err := ParseSort(value, whitelist)
+	if err != nil {
+		return "", err
+	}
+
+	columns := make([]string, len(fields))
+	expression := make([]string, len(fields))
+	for i, field := range fields {
+		direction := "ASC"
+		if field.Desc {
+			direction = "DESC"
+		}
+		columns[i] = field.Column + " " + direction
+		expression[i] = field.String()
+	}
+
+	p.Sort = strings.Join(expression, ",")
+	return strings.Join(columns, ", "), nil
+}
 
-	if p.OrderDir == "" {
-		p.OrderDir = "ASC"
+// MarshalJSON renders only fields relevant for paginator mode
+func (p *Paginator) MarshalJSON() ([]byte, error) {
+	if p.Mode != ModeCursor {
+		type page Paginator
+		return json.Marshal((*page)(p))
 	}
 
-	return fmt.Sprintf("%s %s", p.OrderBy, p.OrderDir)
+	return json.Marshal(struct {
+		Mode               string `json:"mode"`
+		PerPage            int    `json:"per_page"`
+		CurrentEntriesSize int    `json:"current_entries_size"`
+		Sort               string `json:"sort"`
+		Filter             string `json:"filter"`
+		Filters            Filter `json:"filters,omitempty"`
+		Fields             Fields `json:"fields,omitempty"`
+		WithDeleted        bool   `json:"with_deleted"`
+		OnlyDeleted        bool   `json:"only_deleted"`
+		NextCursor         string `json:"next_cursor"`
+		PrevCursor         string `json:"prev_cursor"`
+	}{
+		Mode:               p.Mode,
+		PerPage:            p.PerPage,
+		CurrentEntriesSize: p.CurrentEntriesSize,
+		Sort:               p.Sort,
+		Filter:             p.Filter,
+		Filters:            p.Filters,
+		Fields:             p.Fields,
+		WithDeleted:        p.WithDeleted,
+		OnlyDeleted:        p.OnlyDeleted,
+		NextCursor:         p.NextCursor,
+		PrevCursor:         p.PrevCursor,
+	})
 }
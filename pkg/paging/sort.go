@@ -0,0 +1,96 @@
+package paging
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortWhitelist maps API field names which can be used for sorting to database columns
+type SortWhitelist map[string]string
+
+// Fields returns alphabetically sorted API field names allowed for sorting
+func (w SortWhitelist) Fields() []string {
+	fields := make([]string, 0, len(w))
+	for field := range w {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Only returns whitelist restricted to given API field names
+func (w SortWhitelist) Only(fields ...string) SortWhitelist {
+	only := make(SortWhitelist, len(fields))
+	for _, field := range fields {
+		if column, ok := w[field]; ok {
+			only[field] = column
+		}
+	}
+	return only
+}
+
+// SortField is single column of sort expression
+type SortField struct {
+	// Field is API field name
+	Field string
+	// Column is database column mapped to Field
+	Column string
+	// Desc is true for descending direction
+	Desc bool
+}
+
+// String returns field in sort syntax
+func (f SortField) String() string {
+	if f.Desc {
+		return "-" + f.Field
+	}
+	return f.Field
+}
+
+// SortError is returned when sort expression is not valid for given whitelist
+type SortError struct {
+	// Field is invalid sort field or direction
+	Field string
+	// Allowed are API field names allowed for sorting
+	Allowed []string
+}
+
+// Error implements error interface
+func (e *SortError) Error() string {
+	return fmt.Sprintf("invalid sort `%s`, allowed fields are: %s", e.Field, strings.Join(e.Allowed, ", "))
+}
+
+// ParseSort parses comma separated sort expression, e.g. `-created_at,last_name`
+//
+// fields prefixed with `-` are sorted in descending order, fields without prefix
+// or prefixed with `+` in ascending order. Every field has to be present in whitelist
+// and can be used only once.
+func ParseSort(value string, whitelist SortWhitelist) ([]SortField, error) {
+	fields := make([]SortField, 0)
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+
+		field := SortField{Field: part}
+		switch {
+		case strings.HasPrefix(part, "-"):
+			field.Field = part[1:]
+			field.Desc = true
+		case strings.HasPrefix(part, "+"):
+			field.Field = part[1:]
+		}
+
+		column, ok := whitelist[field.Field]
+		if !ok || seen[field.Field] {
+			return nil, &SortError{Field: part, Allowed: whitelist.Fields()}
+		}
+
+		field.Column = column
+		seen[field.Field] = true
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
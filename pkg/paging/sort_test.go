@@ -0,0 +1,56 @@
+package paging
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSortFields = SortWhitelist{
+	"id":         "id",
+	"last_name":  "last_name",
+	"created_at": "users.created_at",
+}
+
+func TestOrder(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected string
+		sort     string
+		err      bool
+	}{
+		{"", "id ASC", "id", false},
+		{"sort=-created_at,last_name", "users.created_at DESC, last_name ASC", "-created_at,last_name", false},
+		{"sort=+last_name, -id", "last_name ASC, id DESC", "last_name,-id", false},
+		{"order_by=last_name&order_dir=desc", "last_name DESC", "-last_name", false},
+		{"order_by=last_name", "last_name ASC", "last_name", false},
+		{"sort=-email", "", "", true},
+		{"sort=id,-id", "", "", true},
+		{"sort=id,", "", "", true},
+		{"sort=id%3BDROP%20TABLE%20users", "", "", true},
+		{"order_by=id&order_dir=sideways", "", "", true},
+		{"order_by=id desc", "", "", true},
+	}
+
+	for _, c := range cases {
+		params, _ := url.ParseQuery(c.query)
+		paginator := NewPaginatorFromParams(params)
+
+		order, err := paginator.Order(testSortFields, "id")
+		if c.err {
+			assert.IsType(t, &SortError{}, err, "expected sort error for `%s`", c.query)
+			continue
+		}
+
+		assert.Nil(t, err, "unexpected error for `%s`", c.query)
+		assert.Equal(t, c.expected, order, "unexpected order for `%s`", c.query)
+		assert.Equal(t, c.sort, paginator.Sort, "unexpected normalized sort for `%s`", c.query)
+	}
+}
+
+func TestSortErrorListsAllowedFields(t *testing.T) {
+	_, err := ParseSort("email", testSortFields)
+
+	assert.EqualError(t, err, "invalid sort `email`, allowed fields are: created_at, id, last_name")
+}
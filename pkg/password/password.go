@@ -0,0 +1,33 @@
+package password
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Cost is bcrypt cost used to hash passwords
+var Cost = bcrypt.DefaultCost
+
+// dummyHash is used to keep comparison time constant
+// when there is no stored hash to compare against
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.MinCost)
+
+// Hash returns bcrypt hash for given plain text password
+func Hash(plain string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(plain), Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Compare checks if plain text password matches given bcrypt hash
+//
+// when hash is empty comparison is still executed against dummy hash
+// so callers do not reveal missing accounts through response timing
+func Compare(hash, plain string) bool {
+	if hash == "" {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(plain))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(plain)) == nil
+}
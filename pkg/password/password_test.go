@@ -0,0 +1,18 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashAndCompare(t *testing.T) {
+	hash, err := Hash("correct horse battery staple")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, "correct horse battery staple", hash, "expected hash to differ from plain password")
+	assert.True(t, Compare(hash, "correct horse battery staple"), "expected password to match its hash")
+	assert.False(t, Compare(hash, "wrong password"), "expected wrong password not to match hash")
+	assert.False(t, Compare("", "correct horse battery staple"), "expected empty hash never to match")
+}
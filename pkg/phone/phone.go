@@ -0,0 +1,33 @@
+package phone
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/nyaruka/phonenumbers"
+)
+
+// ErrInvalidNumber is returned when phone number cannot be parsed or is not valid
+var ErrInvalidNumber = errors.New("invalid phone number")
+
+// NormalizeE164 returns phone number formatted according to E.164, e.g. `+38761123456`
+//
+// numbers without international prefix are parsed as national numbers
+// of given ISO 3166-1 alpha-2 region. Empty number is returned unchanged.
+func NormalizeE164(number string, region string) (string, error) {
+	number = strings.TrimSpace(number)
+	if number == "" {
+		return "", nil
+	}
+
+	parsed, err := phonenumbers.Parse(number, strings.ToUpper(region))
+	if err != nil {
+		return "", ErrInvalidNumber
+	}
+
+	if !phonenumbers.IsValidNumber(parsed) {
+		return "", ErrInvalidNumber
+	}
+
+	return phonenumbers.Format(parsed, phonenumbers.E164), nil
+}
@@ -0,0 +1,32 @@
+package phone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeE164(t *testing.T) {
+	cases := []struct {
+		number   string
+		region   string
+		expected string
+		err      error
+	}{
+		{"", "", "", nil},
+		{"+387 61 123 456", "", "+38761123456", nil},
+		{"00387 61 123 456", "BA", "+38761123456", nil},
+		{"061/123-456", "BA", "+38761123456", nil},
+		{"(202) 555-0143", "us", "+12025550143", nil},
+		{"061 123 456", "", "", ErrInvalidNumber},
+		{"+387 1", "", "", ErrInvalidNumber},
+		{"not a number", "BA", "", ErrInvalidNumber},
+	}
+
+	for _, c := range cases {
+		normalized, err := NormalizeE164(c.number, c.region)
+
+		assert.Equal(t, c.err, err, "unexpected error for `%s`", c.number)
+		assert.Equal(t, c.expected, normalized, "unexpected normalized value for `%s`", c.number)
+	}
+}
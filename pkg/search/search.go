@@ -0,0 +1,217 @@
+package search
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	// highlightOpen is tag opening highlighted term in snippets
+	highlightOpen = "<mark>"
+
+	// highlightClose is tag closing highlighted term in snippets
+	highlightClose = "</mark>"
+
+	// ellipsis marks text cut from snippet
+	ellipsis = "…"
+)
+
+// Hit is document matching search query
+type Hit struct {
+	ID    uint64
+	Score float64
+}
+
+// Tokenize splits text into lowercase terms made of letters and digits
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// InvertedIndex is in-process full-text index safe for concurrent use
+//
+// documents are scored with TF-IDF, every query term contributes to the score
+// of documents containing it, so documents matching more and rarer terms rank higher
+type InvertedIndex struct {
+	mu       sync.RWMutex
+	postings map[string]map[uint64]int
+	terms    map[uint64][]string
+}
+
+// NewInvertedIndex creates empty InvertedIndex
+func NewInvertedIndex() *InvertedIndex {
+	return &InvertedIndex{
+		postings: make(map[string]map[uint64]int),
+		terms:    make(map[uint64][]string),
+	}
+}
+
+// Add indexes document with given id and text, replacing previously indexed document with the same id
+func (ix *InvertedIndex) Add(id uint64, text ...string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.remove(id)
+
+	terms := Tokenize(strings.Join(text, " "))
+	for _, term := range terms {
+		if ix.postings[term] == nil {
+			ix.postings[term] = make(map[uint64]int)
+		}
+		ix.postings[term][id]++
+	}
+	ix.terms[id] = terms
+}
+
+// Remove removes document with given id from index
+func (ix *InvertedIndex) Remove(id uint64) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.remove(id)
+}
+
+// Len returns number of indexed documents
+func (ix *InvertedIndex) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	return len(ix.terms)
+}
+
+// Search returns at most limit documents matching any query term, ordered by relevance
+func (ix *InvertedIndex) Search(query string, limit int) []Hit {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	scores := make(map[uint64]float64)
+	total := float64(len(ix.terms))
+
+	for _, term := range unique(Tokenize(query)) {
+		postings := ix.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+
+		idf := math.Log(1 + total/float64(len(postings)))
+		for id, frequency := range postings {
+			scores[id] += float64(frequency) / float64(len(ix.terms[id])) * idf
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{ID: id, Score: score})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// remove removes document postings, caller has to hold write lock
+func (ix *InvertedIndex) remove(id uint64) {
+	for _, term := range ix.terms[id] {
+		delete(ix.postings[term], id)
+		if len(ix.postings[term]) == 0 {
+			delete(ix.postings, term)
+		}
+	}
+	delete(ix.terms, id)
+}
+
+// Highlight returns HTML escaped snippet of text around first occurrence of any query term
+// with every occurrence wrapped in <mark> tag
+//
+// snippet contains at most size characters of text around the occurrence,
+// false is returned when text does not contain any query term
+func Highlight(text string, query string, size int) (string, bool) {
+	terms := make(map[string]bool)
+	for _, term := range Tokenize(query) {
+		terms[term] = true
+	}
+
+	runes := []rune(text)
+
+	// find term boundaries in text
+	type span struct{ start, end int }
+	matches := make([]span, 0)
+	for i := 0; i < len(runes); {
+		if !unicode.IsLetter(runes[i]) && !unicode.IsNumber(runes[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsNumber(runes[j])) {
+			j++
+		}
+		if terms[strings.ToLower(string(runes[i:j]))] {
+			matches = append(matches, span{i, j})
+		}
+		i = j
+	}
+
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	// center window around first match
+	start := matches[0].start - size/4
+	if start < 0 {
+		start = 0
+	}
+	end := start + size
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString(ellipsis)
+	}
+
+	position := start
+	for _, m := range matches {
+		if m.start < start || m.end > end {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[position:m.start])))
+		b.WriteString(highlightOpen)
+		b.WriteString(html.EscapeString(string(runes[m.start:m.end])))
+		b.WriteString(highlightClose)
+		position = m.end
+	}
+	b.WriteString(html.EscapeString(string(runes[position:end])))
+
+	if end < len(runes) {
+		b.WriteString(ellipsis)
+	}
+
+	return b.String(), true
+}
+
+// unique returns terms without duplicates, preserving order
+func unique(terms []string) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			result = append(result, term)
+		}
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"sedin", "dugum", "sedin", "mop", "ba", "71000"}, Tokenize("Sedin Dugum <sedin@mop.ba>, 71000"))
+}
+
+func TestInvertedIndexSearch(t *testing.T) {
+	ix := NewInvertedIndex()
+	ix.Add(1, "Sedin", "Dugum", "Sarajevo", "Go developer from Sarajevo")
+	ix.Add(2, "John", "Doe", "Berlin", "Java developer")
+	ix.Add(3, "Jane", "Doe", "Sarajevo", "")
+
+	hits := ix.Search("sarajevo developer", 10)
+
+	assert.Len(t, hits, 3)
+	assert.Equal(t, uint64(1), hits[0].ID, "expected document matching all terms most often to rank first")
+
+	assert.Empty(t, ix.Search("nothing", 10))
+	assert.Len(t, ix.Search("doe", 1), 1, "expected results to be limited")
+}
+
+func TestInvertedIndexReplaceAndRemove(t *testing.T) {
+	ix := NewInvertedIndex()
+	ix.Add(1, "Sedin Dugum")
+	ix.Add(1, "John Doe")
+
+	assert.Empty(t, ix.Search("sedin", 10), "expected replaced document terms to be removed")
+	assert.Len(t, ix.Search("john", 10), 1)
+
+	ix.Remove(1)
+
+	assert.Empty(t, ix.Search("john", 10))
+	assert.Equal(t, 0, ix.Len())
+}
+
+func TestHighlight(t *testing.T) {
+	snippet, ok := Highlight("Go developer from Sarajevo", "sarajevo go", 100)
+	assert.True(t, ok)
+	assert.Equal(t, "<mark>Go</mark> developer from <mark>Sarajevo</mark>", snippet)
+
+	snippet, ok = Highlight("Lorem ipsum dolor sit amet, consectetur adipiscing <b>elit</b>, sed do eiusmod", "elit", 20)
+	assert.True(t, ok)
+	assert.Equal(t, "…g &lt;b&gt;<mark>elit</mark>&lt;/b&gt;, sed d…", snippet)
+
+	_, ok = Highlight("Go developer", "java", 100)
+	assert.False(t, ok)
+
+	_, ok = Highlight("Godeveloper", "go", 100)
+	assert.False(t, ok, "expected only whole terms to be highlighted")
+}
@@ -0,0 +1,82 @@
+package sms
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/go-flow/flow/log"
+)
+
+// Message is text message sent to phone number
+type Message struct {
+	To   string
+	Body string
+}
+
+// SMSSender sends text messages
+type SMSSender interface {
+	Send(msg Message) error
+}
+
+// New creates SMSSender implementation for given driver name
+//
+// supported drivers are `log` and `memory`, production deployments
+// are expected to add their provider implementation here
+func New(driver string, logger log.Logger) (SMSSender, error) {
+	switch driver {
+	case "", "log":
+		return NewLogSender(logger), nil
+	case "memory":
+		return NewMemorySender(), nil
+	}
+
+	return nil, fmt.Errorf("sms: driver `%s` is not supported", driver)
+}
+
+// LogSender writes text messages to application log instead of sending them
+type LogSender struct {
+	logger log.Logger
+}
+
+// NewLogSender creates LogSender instance
+func NewLogSender(logger log.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+// Send writes message to log
+func (s *LogSender) Send(msg Message) error {
+	s.logger.Infof("SMS to %s: %s", msg.To, msg.Body)
+	return nil
+}
+
+// MemorySender keeps sent text messages in memory
+//
+// it is meant for tests which need to inspect sent messages
+type MemorySender struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewMemorySender creates MemorySender instance
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+// Send stores message in memory
+func (s *MemorySender) Send(msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+// Messages returns copy of all sent messages
+func (s *MemorySender) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := make([]Message, len(s.messages))
+	copy(messages, s.messages)
+	return messages
+}
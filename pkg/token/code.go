@@ -0,0 +1,37 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+// NumericCode returns uniformly distributed random numeric code with given number of digits
+func NumericCode(digits int) (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
+
+// HashWithKey returns hex encoded HMAC-SHA256 of given value
+//
+// short codes have too little entropy for plain hashing, keyed hash
+// prevents brute forcing codes from leaked database rows
+func HashWithKey(key string, value string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// EqualHash compares two hex encoded hashes in constant time
+func EqualHash(a string, b string) bool {
+	return hmac.Equal([]byte(a), []byte(b))
+}
@@ -0,0 +1,25 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NumericCode(t *testing.T) {
+	for _, digits := range []int{4, 6, 8} {
+		code, err := NumericCode(digits)
+
+		assert.Nil(t, err, "expected error to be <nil>, got %v", err)
+		assert.Regexp(t, `^[0-9]+$`, code, "expected numeric code, got %s", code)
+		assert.Len(t, code, digits, "expected code with %d digits, got %s", digits, code)
+	}
+}
+
+func Test_HashWithKey(t *testing.T) {
+	hash := HashWithKey("key", "123456")
+
+	assert.True(t, EqualHash(hash, HashWithKey("key", "123456")), "expected hashes of same value and key to match")
+	assert.False(t, EqualHash(hash, HashWithKey("other", "123456")), "expected hashes with different keys to differ")
+	assert.False(t, EqualHash(hash, HashWithKey("key", "654321")), "expected hashes of different values to differ")
+}
@@ -0,0 +1,162 @@
+package token
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+var (
+	// ErrInvalidToken is returned when token cannot be parsed or its signature is not valid
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrExpiredToken is returned when token is no longer valid
+	ErrExpiredToken = errors.New("token is expired")
+)
+
+// Claims are JWT claims issued for authenticated users
+type Claims struct {
+	jwt.StandardClaims
+}
+
+// UserID returns user identifier stored in token subject
+func (c *Claims) UserID() (uint64, error) {
+	return strconv.ParseUint(c.Subject, 10, 64)
+}
+
+// Manager issues and verifies signed JWT access tokens
+type Manager struct {
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+	issuer    string
+}
+
+// NewHMACManager creates Manager which signs tokens using HS256 and given secret
+func NewHMACManager(secret string, issuer string) (*Manager, error) {
+	if secret == "" {
+		return nil, errors.New("token: HS256 secret is empty")
+	}
+
+	return &Manager{
+		method:    jwt.SigningMethodHS256,
+		signKey:   []byte(secret),
+		verifyKey: []byte(secret),
+		issuer:    issuer,
+	}, nil
+}
+
+// NewRSAManager creates Manager which signs tokens using RS256 and given PEM encoded keys
+//
+// private key can be nil when Manager is used only for token verification
+func NewRSAManager(privateKeyPEM []byte, publicKeyPEM []byte, issuer string) (*Manager, error) {
+	var (
+		privateKey *rsa.PrivateKey
+		err        error
+	)
+
+	if len(privateKeyPEM) > 0 {
+		privateKey, err = jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		method:    jwt.SigningMethodRS256,
+		signKey:   privateKey,
+		verifyKey: publicKey,
+		issuer:    issuer,
+	}, nil
+}
+
+// NewManager creates Manager for given signing method name
+//
+// HS256 uses secret, RS256 loads PEM keys from privateKeyPath and publicKeyPath
+func NewManager(method string, secret string, privateKeyPath string, publicKeyPath string, issuer string) (*Manager, error) {
+	switch method {
+	case "", jwt.SigningMethodHS256.Alg():
+		return NewHMACManager(secret, issuer)
+	case jwt.SigningMethodRS256.Alg():
+		var privateKey []byte
+		if privateKeyPath != "" {
+			b, err := ioutil.ReadFile(privateKeyPath)
+			if err != nil {
+				return nil, err
+			}
+			privateKey = b
+		}
+
+		publicKey, err := ioutil.ReadFile(publicKeyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewRSAManager(privateKey, publicKey, issuer)
+	}
+
+	return nil, fmt.Errorf("token: signing method `%s` is not supported", method)
+}
+
+// Issue creates signed token for given user valid for ttl duration
+func (m *Manager) Issue(userID uint64, ttl time.Duration) (string, time.Time, error) {
+	if m.signKey == nil {
+		return "", time.Time{}, errors.New("token: signing key is not configured")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	claims := Claims{
+		StandardClaims: jwt.StandardClaims{
+			Subject:   strconv.FormatUint(userID, 10),
+			Issuer:    m.issuer,
+			IssuedAt:  now.Unix(),
+			NotBefore: now.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(m.method, claims).SignedString(m.signKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, expiresAt, nil
+}
+
+// Parse verifies token signature and returns its claims
+func (m *Manager) Parse(tokenString string) (*Claims, error) {
+	claims := new(Claims)
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		// reject tokens signed with different algorithm, e.g. `none` or HS256 signed with RSA public key
+		if t.Method.Alg() != m.method.Alg() {
+			return nil, ErrInvalidToken
+		}
+		return m.verifyKey, nil
+	})
+
+	if err != nil {
+		if verr, ok := err.(*jwt.ValidationError); ok && verr.Errors&jwt.ValidationErrorExpired != 0 {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	if m.issuer != "" && !claims.VerifyIssuer(m.issuer, true) {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,83 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACManager(t *testing.T) {
+	m, err := NewHMACManager("secret", "test")
+	require.NoError(t, err)
+
+	signed, expiresAt, err := m.Issue(42, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, expiresAt.After(time.Now()), "expected token expiration to be in the future")
+
+	claims, err := m.Parse(signed)
+	require.NoError(t, err)
+
+	userID, err := claims.UserID()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), userID, "expected user id to be 42, got %v", userID)
+
+	// token signed with different secret is rejected
+	other, _ := NewHMACManager("other", "test")
+	_, err = other.Parse(signed)
+	assert.Equal(t, ErrInvalidToken, err, "expected ErrInvalidToken, got %v", err)
+
+	// token from different issuer is rejected
+	other, _ = NewHMACManager("secret", "other")
+	_, err = other.Parse(signed)
+	assert.Equal(t, ErrInvalidToken, err, "expected ErrInvalidToken, got %v", err)
+}
+
+func TestExpiredToken(t *testing.T) {
+	m, err := NewHMACManager("secret", "test")
+	require.NoError(t, err)
+
+	signed, _, err := m.Issue(1, -time.Minute)
+	require.NoError(t, err)
+
+	_, err = m.Parse(signed)
+	assert.Equal(t, ErrExpiredToken, err, "expected ErrExpiredToken, got %v", err)
+}
+
+func TestRSAManager(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+
+	m, err := NewRSAManager(privatePEM, publicPEM, "test")
+	require.NoError(t, err)
+
+	signed, _, err := m.Issue(7, time.Minute)
+	require.NoError(t, err)
+
+	// verification only manager accepts token
+	verifier, err := NewRSAManager(nil, publicPEM, "test")
+	require.NoError(t, err)
+
+	claims, err := verifier.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "7", claims.Subject, "expected subject to be 7, got %v", claims.Subject)
+
+	// HS256 token signed with public key must not be accepted by RS256 manager
+	forged, err := NewHMACManager(string(publicPEM), "test")
+	require.NoError(t, err)
+	signed, _, err = forged.Issue(7, time.Minute)
+	require.NoError(t, err)
+
+	_, err = verifier.Parse(signed)
+	assert.Equal(t, ErrInvalidToken, err, "expected ErrInvalidToken, got %v", err)
+}
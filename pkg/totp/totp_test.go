@@ -0,0 +1,66 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is SHA1 shared secret used by RFC 6238 test vectors
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func Test_CodeAt(t *testing.T) {
+	// RFC 6238 appendix B values truncated to six digits
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := CodeAt(rfcSecret, Step(time.Unix(unix, 0)))
+
+		assert.Nil(t, err, "expected error to be <nil>, got %v", err)
+		assert.Equal(t, expected, code, "expected code for %d to be %s, got %s", unix, expected, code)
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	code, _ := CodeAt(rfcSecret, Step(now))
+
+	step, ok := Validate(rfcSecret, code, now.Add(Period*time.Second), 1)
+	assert.True(t, ok, "expected code from previous step to be accepted")
+	assert.Equal(t, Step(now), step, "expected matched step to be returned")
+
+	_, ok = Validate(rfcSecret, code, now.Add(2*Period*time.Second), 1)
+	assert.False(t, ok, "expected code outside of skew window to be rejected")
+
+	_, ok = Validate(rfcSecret, "12345", now, 1)
+	assert.False(t, ok, "expected code with wrong length to be rejected")
+}
+
+func Test_GenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.Nil(t, err, "expected error to be <nil>, got %v", err)
+
+	_, err = CodeAt(secret, 1)
+	assert.Nil(t, err, "expected generated secret to be valid base32, got %v", err)
+}
+
+func Test_URI(t *testing.T) {
+	uri, err := url.Parse(URI("Template API", "john@example.com", "SECRET"))
+
+	assert.Nil(t, err, "expected error to be <nil>, got %v", err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Template API:john@example.com", uri.Path)
+	assert.Equal(t, "SECRET", uri.Query().Get("secret"))
+	assert.Equal(t, "Template API", uri.Query().Get("issuer"))
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"strings"
+
+	apperrors "github.com/go-flow/template-api/domain/errors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+// MySQL server error numbers translated to domain errors
+const (
+	mysqlErrDuplicateEntry     = 1062
+	mysqlErrRowIsReferenced    = 1451
+	mysqlErrNoReferencedRow    = 1452
+	mysqlErrRowIsReferencedOld = 1217
+	mysqlErrNoReferencedRowOld = 1216
+)
+
+// PostgreSQL error codes translated to domain errors
+const (
+	postgresErrUniqueViolation     = "23505"
+	postgresErrForeignKeyViolation = "23503"
+)
+
+var (
+	// ErrDuplicateRecord is returned when record violates unique constraint
+	ErrDuplicateRecord = apperrors.Conflict(apperrors.CodeDuplicateRecord, "record already exists")
+
+	// ErrRecordReferenced is returned when record cannot be deleted or changed because other records reference it
+	ErrRecordReferenced = apperrors.Conflict(apperrors.CodeRecordReferenced, "record is referenced by other records")
+
+	// ErrInvalidReference is returned when record references record which does not exist
+	ErrInvalidReference = apperrors.Validation(apperrors.CodeInvalidReference, "referenced record does not exist")
+)
+
+// translateError translates database constraint violations to domain errors
+//
+// original database error is kept as underlying error, other errors are returned unchanged
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if domainErr := constraintError(err); domainErr != nil {
+		return domainErr.Wrap(err)
+	}
+
+	return err
+}
+
+// constraintError returns domain error of violated constraint, or nil when err is not constraint violation
+func constraintError(err error) *apperrors.Error {
+	switch derr := err.(type) {
+	case *mysql.MySQLError:
+		switch derr.Number {
+		case mysqlErrDuplicateEntry:
+			return ErrDuplicateRecord
+		case mysqlErrRowIsReferenced, mysqlErrRowIsReferencedOld:
+			return ErrRecordReferenced
+		case mysqlErrNoReferencedRow, mysqlErrNoReferencedRowOld:
+			return ErrInvalidReference
+		}
+	case *pq.Error:
+		switch derr.Code {
+		case postgresErrUniqueViolation:
+			return ErrDuplicateRecord
+		case postgresErrForeignKeyViolation:
+			// same code is used for both sides of constraint, only message tells them apart
+			if strings.HasPrefix(derr.Message, "update or delete on table") {
+				return ErrRecordReferenced
+			}
+			return ErrInvalidReference
+		}
+	default:
+		// sqlite3 error type is available only in cgo builds, its constraint failures are told by message.
+		// SQLite does not report which side of foreign key failed, repositories delete through cascades,
+		// so failure is attributed to written record
+		msg := err.Error()
+		switch {
+		case strings.HasPrefix(msg, "UNIQUE constraint failed"):
+			return ErrDuplicateRecord
+		case strings.HasPrefix(msg, "FOREIGN KEY constraint failed"):
+			return ErrInvalidReference
+		}
+	}
+
+	return nil
+}
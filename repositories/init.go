@@ -5,4 +5,13 @@ import "github.com/go-flow/flow"
 // Init initializes project repositories
 func Init(app *flow.App) {
 	app.Register(NewUserRepository(app))
+	app.Register(NewSearchIndex(app))
+	app.Register(NewRefreshTokenRepository(app))
+	app.Register(NewRoleRepository(app))
+	app.Register(NewUserTokenRepository(app))
+	app.Register(NewOneTimeCodeRepository(app))
+	app.Register(NewTwoFactorRepository(app))
+	app.Register(NewInvitationRepository(app))
+	app.Register(NewTosRepository(app))
+	app.Register(NewUserStatusTransitionRepository(app))
 }
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+)
+
+// InvitationRepository defines set of available operations around Invitation records
+type InvitationRepository interface {
+	// InvitationRepository ensures interface implementation
+	InvitationRepository() string
+
+	// GetByID returns invitation record based on a provided id
+	GetByID(ctx context.Context, id uint64) (*models.Invitation, error)
+
+	// GetByTokenHash returns invitation record based on a provided token hash
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.Invitation, error)
+
+	// GetAllByInviterUserID returns all invitations sent by given user, newest first
+	GetAllByInviterUserID(ctx context.Context, inviterUserID uint64) ([]*models.Invitation, error)
+
+	// CountPendingByEmail returns number of pending, not expired invitations for given email
+	CountPendingByEmail(ctx context.Context, email string) (int, error)
+
+	// Create new Invitation record in database
+	Create(ctx context.Context, invitation *models.Invitation) error
+
+	// UpdateStatus changes invitation status if it is still pending, it reports false otherwise
+	UpdateStatus(ctx context.Context, invitation *models.Invitation, status string) (bool, error)
+}
+
+// NewInvitationRepository creates InvitationRepository interface implementation
+func NewInvitationRepository(app *flow.App) InvitationRepository {
+	return &invitationRepository{}
+}
+
+// invitationRepository struct that implements InvitationRepository interface
+type invitationRepository struct {
+	Store db.Store
+}
+
+// InvitationRepository ensures interface implementation
+func (repo *invitationRepository) InvitationRepository() string {
+	return "invitationRepository"
+}
+
+// GetByID returns invitation record based on a provided id
+func (repo *invitationRepository) GetByID(ctx context.Context, id uint64) (*models.Invitation, error) {
+	model := new(models.Invitation)
+
+	tx := db.WithContext(ctx, repo.Store).Where("id = ?", id).First(model)
+
+	return model, tx.Error
+}
+
+// GetByTokenHash returns invitation record based on a provided token hash
+func (repo *invitationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.Invitation, error) {
+	model := new(models.Invitation)
+
+	tx := db.WithContext(ctx, repo.Store).Where("token_hash = ?", tokenHash).First(model)
+
+	return model, tx.Error
+}
+
+// GetAllByInviterUserID returns all invitations sent by given user, newest first
+func (repo *invitationRepository) GetAllByInviterUserID(ctx context.Context, inviterUserID uint64) ([]*models.Invitation, error) {
+	model := make([]*models.Invitation, 0)
+
+	tx := db.WithContext(ctx, repo.Store).Where("inviter_user_id = ?", inviterUserID).
+		Order("created_at DESC").
+		Order("id DESC").
+		Find(&model)
+
+	return model, tx.Error
+}
+
+// CountPendingByEmail returns number of pending, not expired invitations for given email
+func (repo *invitationRepository) CountPendingByEmail(ctx context.Context, email string) (int, error) {
+	count := 0
+
+	tx := db.WithContext(ctx, repo.Store).Model(&models.Invitation{}).
+		Where("email = ? AND status = ? AND expires_at > ?", email, models.InvitationStatusPending, time.Now()).
+		Count(&count)
+
+	return count, tx.Error
+}
+
+// Create new Invitation record in database
+func (repo *invitationRepository) Create(ctx context.Context, invitation *models.Invitation) error {
+	tx := db.WithContext(ctx, repo.Store).Create(invitation)
+	return translateError(tx.Error)
+}
+
+// UpdateStatus changes invitation status if it is still pending, it reports false otherwise
+//
+// accepted and revoked invitations are final, conditional update
+// prevents accepting and revoking the same invitation concurrently
+func (repo *invitationRepository) UpdateStatus(ctx context.Context, invitation *models.Invitation, status string) (bool, error) {
+	now := time.Now()
+	fields := map[string]interface{}{
+		"status": status,
+	}
+
+	switch status {
+	case models.InvitationStatusAccepted:
+		fields["accepted_at"] = now
+		fields["accepted_user_id"] = invitation.AcceptedUserID
+	case models.InvitationStatusRevoked:
+		fields["revoked_at"] = now
+	}
+
+	tx := db.WithContext(ctx, repo.Store).Model(invitation).
+		Where("status = ?", models.InvitationStatusPending).
+		Updates(fields)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return false, nil
+	}
+
+	invitation.Status = status
+	switch status {
+	case models.InvitationStatusAccepted:
+		invitation.AcceptedAt = &now
+	case models.InvitationStatusRevoked:
+		invitation.RevokedAt = &now
+	}
+
+	return true, nil
+}
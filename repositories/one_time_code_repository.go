@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/jinzhu/gorm"
+)
+
+// OneTimeCodeRepository defines set of available operations around OneTimeCode records
+type OneTimeCodeRepository interface {
+	// OneTimeCodeRepository ensures interface implementation
+	OneTimeCodeRepository() string
+
+	// GetLatestByUserID returns most recently created one time code for given purpose
+	GetLatestByUserID(ctx context.Context, userID uint64, purpose string) (*models.OneTimeCode, error)
+
+	// Create new OneTimeCode record in database
+	Create(ctx context.Context, code *models.OneTimeCode) error
+
+	// IncrementAttempts counts verification attempt, it reports false when maxAttempts were already made
+	IncrementAttempts(ctx context.Context, code *models.OneTimeCode, maxAttempts int) (bool, error)
+
+	// MarkUsed marks one time code as used, it reports false when code was already used
+	MarkUsed(ctx context.Context, code *models.OneTimeCode) (bool, error)
+
+	// InvalidateAllByUserID marks all unused one time codes for given purpose as used
+	InvalidateAllByUserID(ctx context.Context, userID uint64, purpose string) error
+}
+
+// NewOneTimeCodeRepository creates OneTimeCodeRepository interface implementation
+func NewOneTimeCodeRepository(app *flow.App) OneTimeCodeRepository {
+	return &oneTimeCodeRepository{}
+}
+
+// oneTimeCodeRepository struct that implements OneTimeCodeRepository interface
+type oneTimeCodeRepository struct {
+	Store db.Store
+}
+
+// OneTimeCodeRepository ensures interface implementation
+func (repo *oneTimeCodeRepository) OneTimeCodeRepository() string {
+	return "oneTimeCodeRepository"
+}
+
+// GetLatestByUserID returns most recently created one time code for given purpose
+func (repo *oneTimeCodeRepository) GetLatestByUserID(ctx context.Context, userID uint64, purpose string) (*models.OneTimeCode, error) {
+	model := new(models.OneTimeCode)
+
+	tx := db.WithContext(ctx, repo.Store).Where("user_id = ? AND purpose = ?", userID, purpose).
+		Order("created_at DESC").
+		Order("id DESC").
+		First(model)
+
+	return model, tx.Error
+}
+
+// Create new OneTimeCode record in database
+func (repo *oneTimeCodeRepository) Create(ctx context.Context, code *models.OneTimeCode) error {
+	tx := db.WithContext(ctx, repo.Store).Create(code)
+	return translateError(tx.Error)
+}
+
+// IncrementAttempts counts verification attempt, it reports false when maxAttempts were already made
+//
+// attempts are counted with conditional update, so concurrent attempts cannot exceed maxAttempts
+func (repo *oneTimeCodeRepository) IncrementAttempts(ctx context.Context, code *models.OneTimeCode, maxAttempts int) (bool, error) {
+	tx := db.WithContext(ctx, repo.Store).Model(code).Where("attempts < ?", maxAttempts).Update("attempts", gorm.Expr("attempts + 1"))
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return false, nil
+	}
+
+	code.Attempts++
+	return true, nil
+}
+
+// MarkUsed marks one time code as used, it reports false when code was already used
+func (repo *oneTimeCodeRepository) MarkUsed(ctx context.Context, code *models.OneTimeCode) (bool, error) {
+	now := time.Now()
+
+	tx := db.WithContext(ctx, repo.Store).Model(code).Where("used_at IS NULL").Update("used_at", now)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return false, nil
+	}
+
+	code.UsedAt = &now
+	return true, nil
+}
+
+// InvalidateAllByUserID marks all unused one time codes for given purpose as used
+func (repo *oneTimeCodeRepository) InvalidateAllByUserID(ctx context.Context, userID uint64, purpose string) error {
+	tx := db.WithContext(ctx, repo.Store).Model(&models.OneTimeCode{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now())
+	return tx.Error
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+)
+
+// RefreshTokenRepository defines set of available operations around RefreshToken records
+type RefreshTokenRepository interface {
+	// RefreshTokenRepository ensures interface implementation
+	RefreshTokenRepository() string
+
+	// GetByTokenHash returns refresh token record based on a provided token hash
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+
+	// Create new RefreshToken record in database
+	Create(ctx context.Context, token *models.RefreshToken) error
+
+	// Update existing RefreshToken record in database
+	Update(ctx context.Context, token *models.RefreshToken) error
+
+	// Rotate revokes refresh token as replaced by token with given id, it reports false when token was already revoked
+	Rotate(ctx context.Context, token *models.RefreshToken, replacedByID uint64) (bool, error)
+
+	// RevokeAllByUserID revokes all active refresh tokens issued to given user
+	RevokeAllByUserID(ctx context.Context, userID uint64) error
+}
+
+// NewRefreshTokenRepository creates RefreshTokenRepository interface implementation
+func NewRefreshTokenRepository(app *flow.App) RefreshTokenRepository {
+	return &refreshTokenRepository{}
+}
+
+// refreshTokenRepository struct that implements RefreshTokenRepository interface
+type refreshTokenRepository struct {
+	Store db.Store
+}
+
+// RefreshTokenRepository ensures interface implementation
+func (repo *refreshTokenRepository) RefreshTokenRepository() string {
+	return "refreshTokenRepository"
+}
+
+// GetByTokenHash returns refresh token record based on a provided token hash
+func (repo *refreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	model := new(models.RefreshToken)
+
+	tx := db.WithContext(ctx, repo.Store).Where("token_hash = ?", tokenHash).First(model)
+
+	return model, tx.Error
+}
+
+// Create new RefreshToken record in database
+func (repo *refreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	tx := db.WithContext(ctx, repo.Store).Create(token)
+	return translateError(tx.Error)
+}
+
+// Update existing RefreshToken record in database
+func (repo *refreshTokenRepository) Update(ctx context.Context, token *models.RefreshToken) error {
+	tx := db.WithContext(ctx, repo.Store).Save(token)
+	return translateError(tx.Error)
+}
+
+// Rotate revokes refresh token as replaced by token with given id, it reports false when token was already revoked
+//
+// update is conditional so concurrent requests cannot rotate the same token twice
+func (repo *refreshTokenRepository) Rotate(ctx context.Context, token *models.RefreshToken, replacedByID uint64) (bool, error) {
+	now := time.Now()
+
+	tx := db.WithContext(ctx, repo.Store).Model(token).
+		Where("revoked_at IS NULL").
+		Updates(map[string]interface{}{"revoked_at": now, "replaced_by_id": replacedByID})
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return false, nil
+	}
+
+	token.RevokedAt = &now
+	token.ReplacedByID = &replacedByID
+	return true, nil
+}
+
+// RevokeAllByUserID revokes all active refresh tokens issued to given user
+func (repo *refreshTokenRepository) RevokeAllByUserID(ctx context.Context, userID uint64) error {
+	tx := db.WithContext(ctx, repo.Store).Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now())
+	return tx.Error
+}
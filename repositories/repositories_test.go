@@ -1,8 +1,14 @@
 package repositories
 
 import (
+	"database/sql"
 	"database/sql/driver"
+	"testing"
 	"time"
+
+	"github.com/go-flow/template-api/db"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/require"
 )
 
 // AnyTime used to mock time object
@@ -13,3 +19,26 @@ func (a AnyTime) Match(v driver.Value) bool {
 	_, ok := v.(time.Time)
 	return ok
 }
+
+// newSQLiteStore creates in-process SQLite database migrated with application migrations
+//
+// test is skipped when binary is built without cgo, which sqlite3 driver requires
+func newSQLiteStore(t *testing.T) *gorm.DB {
+	conn, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=1")
+	require.NoError(t, err)
+
+	if err = conn.Ping(); err != nil {
+		conn.Close()
+		t.Skipf("sqlite3 is not available: %v", err)
+	}
+
+	// every connection opens its own in-memory database
+	conn.SetMaxOpenConns(1)
+
+	require.NoError(t, db.Migrate(conn, "sqlite3", "../migrations"))
+
+	store, err := gorm.Open("sqlite3", conn)
+	require.NoError(t, err)
+
+	return store
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+)
+
+// RoleRepository defines set of available operations around Role records
+type RoleRepository interface {
+	// RoleRepository ensures interface implementation
+	RoleRepository() string
+
+	// GetByName returns role record based on a provided name
+	GetByName(ctx context.Context, name string) (*models.Role, error)
+
+	// GetByUserID returns all roles assigned to given user
+	GetByUserID(ctx context.Context, userID uint64) ([]*models.Role, error)
+
+	// GetPermissionNamesByUserID returns names of all permissions granted to given user through roles
+	GetPermissionNamesByUserID(ctx context.Context, userID uint64) ([]string, error)
+
+	// AssignToUser assigns role to user, assigning already assigned role is a no-op
+	AssignToUser(ctx context.Context, userID uint64, roleID uint64) error
+
+	// RemoveFromUser removes role from user
+	RemoveFromUser(ctx context.Context, userID uint64, roleID uint64) error
+}
+
+// NewRoleRepository creates RoleRepository interface implementation
+func NewRoleRepository(app *flow.App) RoleRepository {
+	return &roleRepository{}
+}
+
+// roleRepository struct that implements RoleRepository interface
+type roleRepository struct {
+	Store db.Store
+}
+
+// RoleRepository ensures interface implementation
+func (repo *roleRepository) RoleRepository() string {
+	return "roleRepository"
+}
+
+// GetByName returns role record based on a provided name
+func (repo *roleRepository) GetByName(ctx context.Context, name string) (*models.Role, error) {
+	model := new(models.Role)
+
+	tx := db.WithContext(ctx, repo.Store).Where("name = ?", name).First(model)
+
+	return model, tx.Error
+}
+
+// GetByUserID returns all roles assigned to given user
+func (repo *roleRepository) GetByUserID(ctx context.Context, userID uint64) ([]*models.Role, error) {
+	model := make([]*models.Role, 0)
+
+	tx := db.WithContext(ctx, repo.Store).
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).
+		Order("roles.name ASC").
+		Find(&model)
+
+	return model, tx.Error
+}
+
+// GetPermissionNamesByUserID returns names of all permissions granted to given user through roles
+func (repo *roleRepository) GetPermissionNamesByUserID(ctx context.Context, userID uint64) ([]string, error) {
+	names := make([]string, 0)
+
+	tx := db.WithContext(ctx, repo.Store).Table("permissions").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN user_roles ON user_roles.role_id = role_permissions.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Pluck("DISTINCT permissions.name", &names)
+
+	return names, tx.Error
+}
+
+// AssignToUser assigns role to user, assigning already assigned role is a no-op
+func (repo *roleRepository) AssignToUser(ctx context.Context, userID uint64, roleID uint64) error {
+	model := &models.UserRole{UserID: userID, RoleID: roleID}
+
+	tx := db.WithContext(ctx, repo.Store).Where(model).FirstOrCreate(model)
+
+	return translateError(tx.Error)
+}
+
+// RemoveFromUser removes role from user
+func (repo *roleRepository) RemoveFromUser(ctx context.Context, userID uint64, roleID uint64) error {
+	tx := db.WithContext(ctx, repo.Store).Where("user_id = ? AND role_id = ?", userID, roleID).Delete(&models.UserRole{})
+	return translateError(tx.Error)
+}
@@ -0,0 +1,268 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/config"
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/pkg/search"
+)
+
+// searchSnippetSize is maximal number of characters in search result highlights
+const searchSnippetSize = 120
+
+// userSearchColumns are columns covered by users FULLTEXT index
+var userSearchColumns = []string{"first_name", "last_name", "email", "city", "bio"}
+
+// userPublicSearchColumns are columns covered by users public FULLTEXT index
+//
+// private columns are not searched for viewers who cannot see them, since matches would reveal their values
+var userPublicSearchColumns = []string{"first_name", "last_name", "city", "bio"}
+
+// searchColumns returns columns searched for viewer allowed to see private fields or not
+func searchColumns(private bool) []string {
+	if private {
+		return userSearchColumns
+	}
+	return userPublicSearchColumns
+}
+
+// SearchIndex defines full-text search over user records
+type SearchIndex interface {
+	// SearchIndex ensures interface implementation
+	SearchIndex() string
+
+	// Search returns at most limit users matching query, ordered by relevance
+	//
+	// private fields are matched only when private is true
+	Search(ctx context.Context, query string, limit int, private bool) ([]*models.UserSearchResult, error)
+
+	// Index adds user to index or replaces already indexed user
+	Index(ctx context.Context, user *models.User) error
+
+	// Remove removes user with given id from index
+	Remove(ctx context.Context, userID uint64) error
+}
+
+// NewSearchIndex creates SearchIndex implementation selected by configuration
+//
+// `mysql` driver uses FULLTEXT index maintained by database,
+// `memory` driver keeps in-process inverted index which works with any database
+func NewSearchIndex(app *flow.App) SearchIndex {
+	cfg := app.AppConfig.(config.AppConfig)
+
+	switch cfg.SearchDriver {
+	case "mysql":
+		if dialect := cfg.DBConnections[app.Env].DbDialect; dialect != "mysql" {
+			app.Logger.Fatalf("search driver `mysql` is not supported by `%s` database, use `memory` driver", dialect)
+		}
+		return &mysqlSearchIndex{}
+	case "memory":
+		return &memorySearchIndex{index: search.NewInvertedIndex(), public: search.NewInvertedIndex()}
+	}
+
+	app.Logger.Fatalf("unsupported search driver `%s`", cfg.SearchDriver)
+	return nil
+}
+
+// mysqlSearchIndex struct that implements SearchIndex interface using MySQL FULLTEXT index
+type mysqlSearchIndex struct {
+	Store db.Store
+}
+
+// SearchIndex ensures interface implementation
+func (ix *mysqlSearchIndex) SearchIndex() string {
+	return "mysqlSearchIndex"
+}
+
+// userSearchRow is user record with its relevance
+type userSearchRow struct {
+	models.User
+	Score float64
+}
+
+// Search returns at most limit users matching query, ordered by relevance
+//
+// query is matched in natural language mode, so terms shorter than
+// configured minimal token size and stopwords are ignored.
+// Searched columns have to match columns of FULLTEXT index exactly.
+func (ix *mysqlSearchIndex) Search(ctx context.Context, query string, limit int, private bool) ([]*models.UserSearchResult, error) {
+	columns := searchColumns(private)
+	match := "MATCH (" + strings.Join(columns, ",") + ") AGAINST (? IN NATURAL LANGUAGE MODE)"
+
+	rows := make([]*userSearchRow, 0)
+
+	tx := db.WithContext(ctx, ix.Store).Table("users").
+		Select("users.*, "+match+" AS score", query).
+		Where("deleted_at IS NULL").
+		Where(match, query).
+		Order("score DESC").
+		Order("id ASC").
+		Limit(limit).
+		Scan(&rows)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	results := make([]*models.UserSearchResult, len(rows))
+	for i, row := range rows {
+		user := row.User
+		results[i] = newUserSearchResult(&user, row.Score, query, columns)
+	}
+
+	return results, nil
+}
+
+// Index is no-op, FULLTEXT index is maintained by database
+func (ix *mysqlSearchIndex) Index(ctx context.Context, user *models.User) error {
+	return nil
+}
+
+// Remove is no-op, FULLTEXT index is maintained by database
+func (ix *mysqlSearchIndex) Remove(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+// memorySearchIndex struct that implements SearchIndex interface using in-process inverted index
+//
+// index is built from database on first use and kept up to date by user service,
+// public index holds only public columns
+type memorySearchIndex struct {
+	Store db.Store
+
+	index  *search.InvertedIndex
+	public *search.InvertedIndex
+	once   sync.Once
+	err    error
+}
+
+// SearchIndex ensures interface implementation
+func (ix *memorySearchIndex) SearchIndex() string {
+	return "memorySearchIndex"
+}
+
+// Search returns at most limit users matching query, ordered by relevance
+func (ix *memorySearchIndex) Search(ctx context.Context, query string, limit int, private bool) ([]*models.UserSearchResult, error) {
+	if err := ix.load(); err != nil {
+		return nil, err
+	}
+
+	index := ix.public
+	if private {
+		index = ix.index
+	}
+
+	hits := index.Search(query, limit)
+	if len(hits) == 0 {
+		return make([]*models.UserSearchResult, 0), nil
+	}
+
+	ids := make([]uint64, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+
+	users := make([]*models.User, 0)
+	if tx := db.WithContext(ctx, ix.Store).Where("id IN (?)", ids).Find(&users); tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	byID := make(map[uint64]*models.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+
+	results := make([]*models.UserSearchResult, 0, len(hits))
+	for _, hit := range hits {
+		if user, ok := byID[hit.ID]; ok {
+			results = append(results, newUserSearchResult(user, hit.Score, query, searchColumns(private)))
+		}
+	}
+
+	return results, nil
+}
+
+// Index adds user to index or replaces already indexed user
+func (ix *memorySearchIndex) Index(ctx context.Context, user *models.User) error {
+	if err := ix.load(); err != nil {
+		return err
+	}
+
+	ix.add(user)
+	return nil
+}
+
+// Remove removes user with given id from index
+func (ix *memorySearchIndex) Remove(ctx context.Context, userID uint64) error {
+	if err := ix.load(); err != nil {
+		return err
+	}
+
+	ix.index.Remove(userID)
+	ix.public.Remove(userID)
+	return nil
+}
+
+// load indexes all users once
+//
+// index is loaded without request context, so canceled request does not leave index unusable
+func (ix *memorySearchIndex) load() error {
+	ix.once.Do(func() {
+		users := make([]*models.User, 0)
+		if tx := ix.Store.Find(&users); tx.Error != nil {
+			ix.err = tx.Error
+			return
+		}
+
+		for _, user := range users {
+			ix.add(user)
+		}
+	})
+
+	return ix.err
+}
+
+// add adds user to both indexes
+func (ix *memorySearchIndex) add(user *models.User) {
+	ix.index.Add(user.ID, userSearchFields(user, userSearchColumns)...)
+	ix.public.Add(user.ID, userSearchFields(user, userPublicSearchColumns)...)
+}
+
+// userSearchFields returns values of given user search columns, in columns order
+func userSearchFields(user *models.User, columns []string) []string {
+	values := map[string]string{
+		"first_name": user.FirstName,
+		"last_name":  user.LastName,
+		"email":      user.Email,
+		"city":       user.City,
+		"bio":        user.Bio,
+	}
+
+	fields := make([]string, len(columns))
+	for i, column := range columns {
+		fields[i] = values[column]
+	}
+	return fields
+}
+
+// newUserSearchResult creates search result with highlighted snippets of searched columns matching query
+func newUserSearchResult(user *models.User, score float64, query string, columns []string) *models.UserSearchResult {
+	result := &models.UserSearchResult{
+		User:       user,
+		Score:      score,
+		Highlights: make(map[string]string),
+	}
+
+	for i, value := range userSearchFields(user, columns) {
+		if snippet, ok := search.Highlight(value, query, searchSnippetSize); ok {
+			result.Highlights[columns[i]] = snippet
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+)
+
+// TosRepository defines set of available operations around TosVersion and UserTosAcceptance records
+type TosRepository interface {
+	// TosRepository ensures interface implementation
+	TosRepository() string
+
+	// GetCurrent returns most recently published terms of service version
+	GetCurrent(ctx context.Context) (*models.TosVersion, error)
+
+	// Create new TosVersion record in database
+	Create(ctx context.Context, version *models.TosVersion) error
+
+	// GetAcceptance returns acceptance of given terms of service version by given user
+	GetAcceptance(ctx context.Context, userID uint64, tosVersionID uint64) (*models.UserTosAcceptance, error)
+
+	// GetAcceptancesByUserID returns terms of service acceptance history of given user, newest first
+	GetAcceptancesByUserID(ctx context.Context, userID uint64) ([]*models.UserTosAcceptance, error)
+
+	// CreateAcceptance creates new UserTosAcceptance record in database
+	CreateAcceptance(ctx context.Context, acceptance *models.UserTosAcceptance) error
+}
+
+// NewTosRepository creates TosRepository interface implementation
+func NewTosRepository(app *flow.App) TosRepository {
+	return &tosRepository{}
+}
+
+// tosRepository struct that implements TosRepository interface
+type tosRepository struct {
+	Store db.Store
+}
+
+// TosRepository ensures interface implementation
+func (repo *tosRepository) TosRepository() string {
+	return "tosRepository"
+}
+
+// GetCurrent returns most recently published terms of service version
+//
+// versions with publish date in the future are not current yet
+func (repo *tosRepository) GetCurrent(ctx context.Context) (*models.TosVersion, error) {
+	model := new(models.TosVersion)
+
+	tx := db.WithContext(ctx, repo.Store).Where("published_at <= ?", time.Now()).
+		Order("published_at DESC").
+		Order("id DESC").
+		First(model)
+
+	return model, tx.Error
+}
+
+// Create new TosVersion record in database
+func (repo *tosRepository) Create(ctx context.Context, version *models.TosVersion) error {
+	tx := db.WithContext(ctx, repo.Store).Create(version)
+	return translateError(tx.Error)
+}
+
+// GetAcceptance returns acceptance of given terms of service version by given user
+func (repo *tosRepository) GetAcceptance(ctx context.Context, userID uint64, tosVersionID uint64) (*models.UserTosAcceptance, error) {
+	model := new(models.UserTosAcceptance)
+
+	tx := db.WithContext(ctx, repo.Store).Where("user_id = ? AND tos_version_id = ?", userID, tosVersionID).First(model)
+
+	return model, tx.Error
+}
+
+// GetAcceptancesByUserID returns terms of service acceptance history of given user, newest first
+func (repo *tosRepository) GetAcceptancesByUserID(ctx context.Context, userID uint64) ([]*models.UserTosAcceptance, error) {
+	model := make([]*models.UserTosAcceptance, 0)
+
+	tx := db.WithContext(ctx, repo.Store).Preload("TosVersion").
+		Where("user_id = ?", userID).
+		Order("accepted_at DESC").
+		Find(&model)
+
+	return model, tx.Error
+}
+
+// CreateAcceptance creates new UserTosAcceptance record in database
+func (repo *tosRepository) CreateAcceptance(ctx context.Context, acceptance *models.UserTosAcceptance) error {
+	tx := db.WithContext(ctx, repo.Store).Create(acceptance)
+	return translateError(tx.Error)
+}
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+)
+
+// TwoFactorRepository defines set of available operations around TwoFactorCredential and TwoFactorRecoveryCode records
+type TwoFactorRepository interface {
+	// TwoFactorRepository ensures interface implementation
+	TwoFactorRepository() string
+
+	// GetByUserID returns two-factor credential of given user
+	GetByUserID(ctx context.Context, userID uint64) (*models.TwoFactorCredential, error)
+
+	// Save creates or updates two-factor credential
+	Save(ctx context.Context, credential *models.TwoFactorCredential) error
+
+	// UpdateLastUsedStep stores last accepted time step, it reports false when step was already used
+	UpdateLastUsedStep(ctx context.Context, credential *models.TwoFactorCredential, step int64) (bool, error)
+
+	// DeleteByUserID removes two-factor credential and recovery codes of given user
+	DeleteByUserID(ctx context.Context, userID uint64) error
+
+	// ReplaceRecoveryCodes removes existing recovery codes of given user and stores new ones
+	ReplaceRecoveryCodes(ctx context.Context, userID uint64, codes []*models.TwoFactorRecoveryCode) error
+
+	// MarkRecoveryCodeUsed marks unused recovery code as used, it reports false when no such code exists
+	MarkRecoveryCodeUsed(ctx context.Context, userID uint64, codeHash string) (bool, error)
+}
+
+// NewTwoFactorRepository creates TwoFactorRepository interface implementation
+func NewTwoFactorRepository(app *flow.App) TwoFactorRepository {
+	return &twoFactorRepository{}
+}
+
+// twoFactorRepository struct that implements TwoFactorRepository interface
+type twoFactorRepository struct {
+	Store db.Store
+}
+
+// TwoFactorRepository ensures interface implementation
+func (repo *twoFactorRepository) TwoFactorRepository() string {
+	return "twoFactorRepository"
+}
+
+// GetByUserID returns two-factor credential of given user
+func (repo *twoFactorRepository) GetByUserID(ctx context.Context, userID uint64) (*models.TwoFactorCredential, error) {
+	model := new(models.TwoFactorCredential)
+
+	tx := db.WithContext(ctx, repo.Store).Where("user_id = ?", userID).First(model)
+
+	return model, tx.Error
+}
+
+// Save creates or updates two-factor credential
+func (repo *twoFactorRepository) Save(ctx context.Context, credential *models.TwoFactorCredential) error {
+	tx := db.WithContext(ctx, repo.Store).Save(credential)
+	return translateError(tx.Error)
+}
+
+// UpdateLastUsedStep stores last accepted time step, it reports false when step was already used
+func (repo *twoFactorRepository) UpdateLastUsedStep(ctx context.Context, credential *models.TwoFactorCredential, step int64) (bool, error) {
+	tx := db.WithContext(ctx, repo.Store).Model(credential).Where("last_used_step < ?", step).Update("last_used_step", step)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return false, nil
+	}
+
+	credential.LastUsedStep = step
+	return true, nil
+}
+
+// DeleteByUserID removes two-factor credential and recovery codes of given user
+func (repo *twoFactorRepository) DeleteByUserID(ctx context.Context, userID uint64) error {
+	store := db.WithContext(ctx, repo.Store)
+
+	tx := store.Delete(&models.TwoFactorRecoveryCode{}, "user_id = ?", userID)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	tx = store.Delete(&models.TwoFactorCredential{}, "user_id = ?", userID)
+	return tx.Error
+}
+
+// ReplaceRecoveryCodes removes existing recovery codes of given user and stores new ones
+func (repo *twoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID uint64, codes []*models.TwoFactorRecoveryCode) error {
+	store := db.WithContext(ctx, repo.Store)
+
+	tx := store.Delete(&models.TwoFactorRecoveryCode{}, "user_id = ?", userID)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	for _, code := range codes {
+		code.UserID = userID
+		if tx := store.Create(code); tx.Error != nil {
+			return translateError(tx.Error)
+		}
+	}
+
+	return nil
+}
+
+// MarkRecoveryCodeUsed marks unused recovery code as used, it reports false when no such code exists
+func (repo *twoFactorRepository) MarkRecoveryCodeUsed(ctx context.Context, userID uint64, codeHash string) (bool, error) {
+	tx := db.WithContext(ctx, repo.Store).Model(&models.TwoFactorRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	return tx.RowsAffected > 0, nil
+}
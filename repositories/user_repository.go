@@ -1,12 +1,15 @@
 package repositories
 
 import (
-	"fmt"
+	"context"
+	"time"
 
 	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/config"
 	"github.com/go-flow/template-api/db"
 	"github.com/go-flow/template-api/domain/models"
 	"github.com/go-flow/template-api/pkg/paging"
+	"github.com/jinzhu/gorm"
 )
 
 // UserRepository defines set of available operations around Users record
@@ -15,38 +18,105 @@ type UserRepository interface {
 	UserRepository() string
 
 	// GetByID returns user record based on a provided id
-	GetByID(id uint64) (*models.User, error)
+	GetByID(ctx context.Context, id uint64) (*models.User, error)
+
+	// GetByIDWithFields returns user record based on a provided id with only fieldset columns loaded
+	GetByIDWithFields(ctx context.Context, id uint64, fields paging.Fields) (*models.User, error)
 
 	// GetByEmail returns user record based on a provided email
-	GetByEmail(email string) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
 
 	// GetAll returns all Users records for given paging users
-	GetAll(paginator *paging.Paginator) ([]*models.User, error)
+	GetAll(ctx context.Context, paginator *paging.Paginator) ([]*models.User, error)
+
+	// GetAllByInvitedByUserIDs returns all users invited by any of given users
+	GetAllByInvitedByUserIDs(ctx context.Context, inviterIDs []uint64) ([]*models.User, error)
 
 	// Create new User record in database
-	Create(user *models.User) error
+	Create(ctx context.Context, user *models.User) error
 
 	// Update existing User record in database
-	Update(user *models.User) error
+	Update(ctx context.Context, user *models.User) error
 
 	// Save creates or updates user record based on ID user
-	Save(user *models.User) error
+	Save(ctx context.Context, user *models.User) error
+
+	// Delete soft deletes User record
+	Delete(ctx context.Context, user *models.User) error
 
-	// Delete User record from database
-	Delete(user *models.User) error
+	// DeleteByID soft deletes user record
+	DeleteByID(ctx context.Context, id uint64) error
 
-	// DeleteByID user record from database
-	DeleteByID(id uint64) error
+	// Restore clears deletion of soft deleted user record
+	Restore(ctx context.Context, id uint64) error
+
+	// Purge permanently deletes user record, whether it is soft deleted or not
+	Purge(ctx context.Context, id uint64) error
+
+	// PurgeDeletedBefore permanently deletes user records soft deleted before given time
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
+// userSortFields maps user API fields allowed for sorting to columns
+var userSortFields = paging.SortWhitelist{
+	"id":         "id",
+	"email":      "email",
+	"first_name": "first_name",
+	"last_name":  "last_name",
+	"country":    "country",
+	"city":       "city",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// userFilterFields maps user API fields allowed for filtering to columns and types
+var userFilterFields = paging.FilterWhitelist{
+	"id":                 {Column: "id", Type: paging.FilterInt},
+	"email":              {Column: "email", Type: paging.FilterString},
+	"first_name":         {Column: "first_name", Type: paging.FilterString},
+	"last_name":          {Column: "last_name", Type: paging.FilterString},
+	"phone_number":       {Column: "phone_number", Type: paging.FilterString},
+	"country":            {Column: "country", Type: paging.FilterString},
+	"state":              {Column: "state", Type: paging.FilterString},
+	"area":               {Column: "area", Type: paging.FilterString},
+	"city":               {Column: "city", Type: paging.FilterString},
+	"post_code":          {Column: "post_code", Type: paging.FilterString},
+	"birth_date":         {Column: "birth_date", Type: paging.FilterTime},
+	"is_email_verified":  {Column: "is_email_verified", Type: paging.FilterBool},
+	"is_phone_verified":  {Column: "is_phone_verified", Type: paging.FilterBool},
+	"is_active":          {Column: "is_active", Type: paging.FilterBool},
+	"tos_accepted":       {Column: "tos_accepted", Type: paging.FilterBool},
+	"invited_by_user_id": {Column: "invited_by_user_id", Type: paging.FilterInt},
+	"created_at":         {Column: "created_at", Type: paging.FilterTime},
+	"updated_at":         {Column: "updated_at", Type: paging.FilterTime},
+	"deleted_at":         {Column: "deleted_at", Type: paging.FilterTime},
+}
+
+// userPublicFields are user API fields visible to every viewer
+//
+// other fields can be used for sorting and filtering only by viewers allowed to see them,
+// otherwise their values could be enumerated through matching users
+var userPublicFields = []string{"id", "first_name", "last_name", "country", "city", "created_at"}
+
+var (
+	userPublicSortFields   = userSortFields.Only(userPublicFields...)
+	userPublicFilterFields = userFilterFields.Only(userPublicFields...)
+)
+
 // NewUserRepository creates UserRepository interface implementation
 func NewUserRepository(app *flow.App) UserRepository {
-	return &userRepository{}
+	cfg := app.AppConfig.(config.AppConfig)
+
+	return &userRepository{
+		cursors: paging.NewCursorCodec(cfg.PagingCursorSecret),
+	}
 }
 
 // userRepository struct that implements userRepository interface
 type userRepository struct {
 	Store db.Store
+
+	cursors *paging.CursorCodec
 }
 
 // UseruserRepository ensures interface implementation
@@ -55,41 +125,95 @@ func (repo *userRepository) UserRepository() string {
 }
 
 // GetByID returns user model based on a provided id
-func (repo *userRepository) GetByID(id uint64) (*models.User, error) {
+func (repo *userRepository) GetByID(ctx context.Context, id uint64) (*models.User, error) {
 	model := new(models.User)
 
-	tx := repo.Store.Where("id = ?", id).First(model)
+	tx := db.WithContext(ctx, repo.Store).Where("id = ?", id).First(model)
+
+	return model, tx.Error
+}
+
+// GetByIDWithFields returns user model based on a provided id with only fieldset columns loaded
+//
+// id is always loaded, and all columns are loaded for empty fieldset
+func (repo *userRepository) GetByIDWithFields(ctx context.Context, id uint64, fields paging.Fields) (*models.User, error) {
+	columns := fields.Columns(models.UserFields, "id")
+	if columns == nil {
+		return repo.GetByID(ctx, id)
+	}
+
+	model := new(models.User)
+
+	tx := db.WithContext(ctx, repo.Store).Select(columns).Where("id = ?", id).First(model)
 
 	return model, tx.Error
 }
 
 // GetByEmail returns user model based on a provided email
-func (repo *userRepository) GetByEmail(email string) (*models.User, error) {
+func (repo *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	model := new(models.User)
 
-	tx := repo.Store.Where("email = ?", email).First(model)
+	tx := db.WithContext(ctx, repo.Store).Where("email = ?", email).First(model)
 
 	return model, tx.Error
 }
 
 // GetAll returns all Users model for given paging users
-func (repo *userRepository) GetAll(paginator *paging.Paginator) ([]*models.User, error) {
-	model := make([]*models.User, 0)
+//
+// only public fields can be sorted and filtered on unless paginator is private.
+// *paging.SortError or *paging.FilterError is returned when paginator sort or filters are not allowed for users,
+// and paging.ErrInvalidCursor when paginator is in cursor mode with invalid cursor
+func (repo *userRepository) GetAll(ctx context.Context, paginator *paging.Paginator) ([]*models.User, error) {
+	query := db.WithContext(ctx, repo.Store)
 
-	//construct base query
-	query := repo.Store.
-		Limit(paginator.PerPage).
-		Offset(paginator.Offset).
-		Order(paginator.Order("id"))
+	sortFields, filterFields := userPublicSortFields, userPublicFilterFields
+	if paginator.Private {
+		sortFields, filterFields = userSortFields, userFilterFields
+	}
+
+	// include soft deleted records
+	if paginator.OnlyDeleted {
+		query = query.Unscoped().Where("deleted_at IS NOT NULL")
+	} else if paginator.WithDeleted {
+		query = query.Unscoped()
+	}
 
-		// apply filtering
+	// apply filtering
 	if len(paginator.Filter) > 2 {
-		filter := fmt.Sprintf("%%%s", paginator.Filter)
-		query = query.Where("first_name LIKE ?", filter).Or("last_name LIKE ?", filter)
+		filter := paging.LikePattern(paginator.Filter)
+		query = query.Where(paging.LikeClause("first_name")+" OR "+paging.LikeClause("last_name"), filter, filter)
+	}
+
+	where, args, err := paginator.Filters.Where(filterFields)
+	if err != nil {
+		return nil, err
+	}
+	if where != "" {
+		query = query.Where(where, args...)
+	}
+
+	if paginator.Mode == paging.ModeCursor {
+		return repo.getAllByCursor(query, paginator, sortFields)
+	}
+
+	order, err := paginator.Order(sortFields, "id")
+	if err != nil {
+		return nil, err
 	}
 
-	tx := query.Find(&model).
-		Offset(0).Limit(-1).Count(&paginator.TotalEntriesSize)
+	if columns := paginator.Fields.Columns(models.UserFields, "id"); columns != nil {
+		query = query.Select(columns)
+	}
+
+	model := make([]*models.User, 0)
+
+	// negative offset and limit are omitted from count query, SQLite rejects OFFSET without LIMIT
+	tx := query.
+		Limit(paginator.PerPage).
+		Offset(paginator.Offset).
+		Order(order).
+		Find(&model).
+		Offset(-1).Limit(-1).Count(&paginator.TotalEntriesSize)
 
 	paginator.CurrentEntriesSize = len(model)
 	paginator.TotalPages = paginator.TotalEntriesSize / paginator.PerPage
@@ -100,33 +224,155 @@ func (repo *userRepository) GetAll(paginator *paging.Paginator) ([]*models.User,
 	return model, tx.Error
 }
 
+// getAllByCursor returns page of users addressed by paginator cursor
+//
+// total number of users is not counted in cursor mode
+func (repo *userRepository) getAllByCursor(query db.Store, paginator *paging.Paginator, sortFields paging.SortWhitelist) ([]*models.User, error) {
+	keyset, err := paginator.Keyset(sortFields, "id", "id", repo.cursors)
+	if err != nil {
+		return nil, err
+	}
+
+	if condition, args := keyset.Condition(); condition != "" {
+		query = query.Where(condition, args...)
+	}
+
+	// sort fields are always loaded, cursors are built from their values
+	required := make([]string, len(keyset.Fields))
+	for i, field := range keyset.Fields {
+		required[i] = field.Field
+	}
+	if columns := paginator.Fields.Columns(models.UserFields, required...); columns != nil {
+		query = query.Select(columns)
+	}
+
+	model := make([]*models.User, 0)
+
+	tx := query.Limit(keyset.Limit()).Order(keyset.Order()).Find(&model)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	more := len(model) > paginator.PerPage
+	if more {
+		model = model[:paginator.PerPage]
+	}
+
+	// backward page is fetched in reversed order
+	if keyset.Backward() {
+		for i, j := 0, len(model)-1; i < j; i, j = i+1, j-1 {
+			model[i], model[j] = model[j], model[i]
+		}
+	}
+
+	err = keyset.SetCursors(paginator, len(model), more, func(i int, field string) interface{} {
+		return userSortValue(model[i], field)
+	})
+
+	return model, err
+}
+
+// GetAllByInvitedByUserIDs returns all users invited by any of given users
+func (repo *userRepository) GetAllByInvitedByUserIDs(ctx context.Context, inviterIDs []uint64) ([]*models.User, error) {
+	model := make([]*models.User, 0)
+
+	tx := db.WithContext(ctx, repo.Store).Where("invited_by_user_id IN (?)", inviterIDs).
+		Order("id ASC").
+		Find(&model)
+
+	return model, tx.Error
+}
+
 // Create new User object in database
-func (repo *userRepository) Create(user *models.User) error {
-	tx := repo.Store.Create(user)
-	return tx.Error
+func (repo *userRepository) Create(ctx context.Context, user *models.User) error {
+	tx := db.WithContext(ctx, repo.Store).Create(user)
+	return translateError(tx.Error)
 }
 
 // Update existing User object in database
-func (repo *userRepository) Update(user *models.User) error {
-	tx := repo.Store.Save(user)
-	return tx.Error
+func (repo *userRepository) Update(ctx context.Context, user *models.User) error {
+	tx := db.WithContext(ctx, repo.Store).Save(user)
+	return translateError(tx.Error)
 }
 
 // Save creates or updates user model based on ID user
-func (repo *userRepository) Save(user *models.User) error {
+func (repo *userRepository) Save(ctx context.Context, user *models.User) error {
 	if user.ID > 0 {
-		return repo.Update(user)
+		return repo.Update(ctx, user)
+	}
+	return repo.Create(ctx, user)
+}
+
+// Delete soft deletes user record
+func (repo *userRepository) Delete(ctx context.Context, user *models.User) error {
+	return repo.DeleteByID(ctx, user.ID)
+}
+
+// DeleteByID soft deletes user record
+func (repo *userRepository) DeleteByID(ctx context.Context, id uint64) error {
+	tx := db.WithContext(ctx, repo.Store).Delete(&models.User{ID: id})
+	return translateError(tx.Error)
+}
+
+// Restore clears deletion of soft deleted user record
+//
+// gorm.ErrRecordNotFound is returned when there is no soft deleted user with given id
+func (repo *userRepository) Restore(ctx context.Context, id uint64) error {
+	tx := db.WithContext(ctx, repo.Store).Unscoped().
+		Model(&models.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Purge permanently deletes user record, whether it is soft deleted or not
+//
+// gorm.ErrRecordNotFound is returned when there is no user with given id
+func (repo *userRepository) Purge(ctx context.Context, id uint64) error {
+	tx := db.WithContext(ctx, repo.Store).Unscoped().Delete(&models.User{ID: id})
+
+	if tx.Error != nil {
+		return translateError(tx.Error)
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
-	return repo.Create(user)
+	return nil
 }
 
-// Delete user record from database
-func (repo *userRepository) Delete(user *models.User) error {
-	return repo.DeleteByID(user.ID)
+// PurgeDeletedBefore permanently deletes user records soft deleted before given time
+func (repo *userRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	tx := db.WithContext(ctx, repo.Store).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&models.User{})
+
+	return tx.RowsAffected, tx.Error
 }
 
-// DeleteByID user record from database
-func (repo *userRepository) DeleteByID(id uint64) error {
-	tx := repo.Store.Unscoped().Delete(&models.User{ID: id})
-	return tx.Error
+// userSortValue returns value of user field allowed for sorting
+func userSortValue(user *models.User, field string) interface{} {
+	switch field {
+	case "email":
+		return user.Email
+	case "first_name":
+		return user.FirstName
+	case "last_name":
+		return user.LastName
+	case "country":
+		return user.Country
+	case "city":
+		return user.City
+	case "created_at":
+		return user.CreatedAt
+	case "updated_at":
+		return user.UpdatedAt
+	}
+	return user.ID
 }
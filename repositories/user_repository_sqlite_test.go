@@ -0,0 +1,283 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/pkg/paging"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// UserRepositorySQLiteSuite runs UserRepository queries against in-process SQLite database
+type UserRepositorySQLiteSuite struct {
+	suite.Suite
+	DB *gorm.DB
+
+	repository UserRepository
+}
+
+// TestUserRepositorySQLiteSuite is user repository SQLite test suite runner
+func TestUserRepositorySQLiteSuite(t *testing.T) {
+	suite.Run(t, new(UserRepositorySQLiteSuite))
+}
+
+// SetupTest creates fresh database for every test
+func (s *UserRepositorySQLiteSuite) SetupTest() {
+	s.DB = newSQLiteStore(s.T())
+
+	s.repository = &userRepository{
+		Store:   s.DB,
+		cursors: paging.NewCursorCodec("secret"),
+	}
+}
+
+// TearDownTest closes test database
+func (s *UserRepositorySQLiteSuite) TearDownTest() {
+	if s.DB != nil {
+		s.DB.Close()
+	}
+}
+
+// createUser creates user with given email and country
+func (s *UserRepositorySQLiteSuite) createUser(email, country string) *models.User {
+	user := &models.User{FirstName: "Sedin", LastName: "Dugum", Email: email, Country: country, IsActive: true}
+	require.NoError(s.T(), s.repository.Create(context.Background(), user))
+	return user
+}
+
+func (s *UserRepositorySQLiteSuite) Test_CreateAndGet() {
+	created := s.createUser("sedin@mop.ba", "BA")
+	assert.NotZero(s.T(), created.ID)
+
+	user, err := s.repository.GetByID(context.Background(), created.ID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "sedin@mop.ba", user.Email)
+	assert.True(s.T(), user.IsActive)
+	assert.False(s.T(), user.CreatedAt.IsZero())
+
+	user, err = s.repository.GetByEmail(context.Background(), "sedin@mop.ba")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), created.ID, user.ID)
+
+	_, err = s.repository.GetByEmail(context.Background(), "missing@mop.ba")
+	assert.True(s.T(), gorm.IsRecordNotFoundError(err), "Expected record not found, got %v", err)
+}
+
+func (s *UserRepositorySQLiteSuite) Test_Create_Duplicate() {
+	s.createUser("sedin@mop.ba", "BA")
+
+	err := s.repository.Create(context.Background(), &models.User{Email: "sedin@mop.ba"})
+
+	assert.True(s.T(), errors.Is(err, ErrDuplicateRecord), "Expected duplicate record error, got %v", err)
+}
+
+func (s *UserRepositorySQLiteSuite) Test_Create_InvalidReference() {
+	inviterID := uint64(42)
+
+	err := s.repository.Create(context.Background(), &models.User{Email: "sedin@mop.ba", InvitedByUserID: &inviterID})
+
+	assert.True(s.T(), errors.Is(err, ErrInvalidReference), "Expected invalid reference error, got %v", err)
+}
+
+func (s *UserRepositorySQLiteSuite) Test_Update() {
+	user := s.createUser("sedin@mop.ba", "BA")
+
+	user.City = "Sarajevo"
+	require.NoError(s.T(), s.repository.Save(context.Background(), user))
+
+	updated, err := s.repository.GetByID(context.Background(), user.ID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "Sarajevo", updated.City)
+}
+
+func (s *UserRepositorySQLiteSuite) Test_GetAll() {
+	s.createUser("sedin@mop.ba", "BA")
+	s.createUser("sedin+1@mop.ba", "DE")
+	s.createUser("sedin+2@mop.ba", "DE")
+
+	paginator := paging.NewPaginatorFromParams(url.Values{"filter[country]": {"DE"}, "per_page": {"1"}, "order_by": {"email"}, "order_dir": {"desc"}})
+	paginator.Private = true
+	users, err := s.repository.GetAll(context.Background(), paginator)
+
+	require.NoError(s.T(), err)
+	require.Len(s.T(), users, 1)
+	assert.Equal(s.T(), "sedin+2@mop.ba", users[0].Email)
+	assert.Equal(s.T(), 2, paginator.TotalEntriesSize)
+	assert.Equal(s.T(), 2, paginator.TotalPages)
+}
+
+func (s *UserRepositorySQLiteSuite) Test_GetAll_LikeMatchesLiterally() {
+	s.createUser("sedin_1@mop.ba", "BA")
+	s.createUser("sedinx1@mop.ba", "BA")
+	s.createUser("100%@mop.ba", "BA")
+	s.createUser("1000@mop.ba", "BA")
+
+	cases := map[string]string{
+		"sedin_1": "sedin_1@mop.ba",
+		"100%":    "100%@mop.ba",
+	}
+
+	for value, email := range cases {
+		paginator := paging.NewPaginatorFromParams(url.Values{"filter[email][like]": {value}})
+		paginator.Private = true
+		users, err := s.repository.GetAll(context.Background(), paginator)
+
+		require.NoError(s.T(), err)
+		require.Len(s.T(), users, 1, "expected `%s` to match literally", value)
+		assert.Equal(s.T(), email, users[0].Email)
+	}
+}
+
+func (s *UserRepositorySQLiteSuite) Test_GetAll_PrivateFields() {
+	s.createUser("sedin@mop.ba", "BA")
+
+	params := []url.Values{
+		{"filter[email][like]": {"sedin*"}},
+		{"filter[deleted_at][is-null]": {"false"}},
+		{"sort": {"email"}},
+	}
+
+	for _, values := range params {
+		_, err := s.repository.GetAll(context.Background(), paging.NewPaginatorFromParams(values))
+		assert.Error(s.T(), err, "expected %v to be rejected for public paginator", values)
+
+		paginator := paging.NewPaginatorFromParams(values)
+		paginator.Private = true
+		_, err = s.repository.GetAll(context.Background(), paginator)
+		assert.NoError(s.T(), err, "expected %v to be allowed for private paginator", values)
+	}
+}
+
+func (s *UserRepositorySQLiteSuite) Test_GetAll_LegacyFilter() {
+	user := &models.User{FirstName: "Ann_a", LastName: "Dugum", Email: "sedin@mop.ba"}
+	require.NoError(s.T(), s.repository.Create(context.Background(), user))
+	other := &models.User{FirstName: "Annxa", LastName: "Dugum", Email: "sedin+1@mop.ba"}
+	require.NoError(s.T(), s.repository.Create(context.Background(), other))
+
+	paginator := paging.NewPaginatorFromParams(url.Values{"filter": {"nn_"}})
+	users, err := s.repository.GetAll(context.Background(), paginator)
+
+	require.NoError(s.T(), err)
+	require.Len(s.T(), users, 1)
+	assert.Equal(s.T(), user.ID, users[0].ID)
+}
+
+func (s *UserRepositorySQLiteSuite) Test_DeleteAndRestore() {
+	user := s.createUser("sedin@mop.ba", "BA")
+
+	require.NoError(s.T(), s.repository.DeleteByID(context.Background(), user.ID))
+
+	_, err := s.repository.GetByID(context.Background(), user.ID)
+	assert.True(s.T(), gorm.IsRecordNotFoundError(err), "Expected record not found, got %v", err)
+
+	paginator := paging.NewWithDefaults()
+	paginator.OnlyDeleted = true
+	users, err := s.repository.GetAll(context.Background(), paginator)
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), users, 1)
+
+	require.NoError(s.T(), s.repository.Restore(context.Background(), user.ID))
+	assert.Equal(s.T(), gorm.ErrRecordNotFound, s.repository.Restore(context.Background(), user.ID))
+
+	_, err = s.repository.GetByID(context.Background(), user.ID)
+	assert.NoError(s.T(), err)
+}
+
+func (s *UserRepositorySQLiteSuite) Test_PurgeDeletedBefore() {
+	deleted := s.createUser("sedin@mop.ba", "BA")
+	kept := s.createUser("sedin+1@mop.ba", "BA")
+	require.NoError(s.T(), s.repository.DeleteByID(context.Background(), deleted.ID))
+
+	purged, err := s.repository.PurgeDeletedBefore(context.Background(), time.Now().Add(time.Minute))
+
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1), purged)
+	assert.Equal(s.T(), gorm.ErrRecordNotFound, s.repository.Purge(context.Background(), deleted.ID))
+	assert.NoError(s.T(), s.repository.Purge(context.Background(), kept.ID))
+}
+
+func (s *UserRepositorySQLiteSuite) Test_GetAllByInvitedByUserIDs() {
+	inviter := s.createUser("sedin@mop.ba", "BA")
+	invited := &models.User{Email: "sedin+1@mop.ba", InvitedByUserID: &inviter.ID}
+	require.NoError(s.T(), s.repository.Create(context.Background(), invited))
+
+	users, err := s.repository.GetAllByInvitedByUserIDs(context.Background(), []uint64{inviter.ID})
+
+	require.NoError(s.T(), err)
+	require.Len(s.T(), users, 1)
+	assert.Equal(s.T(), invited.ID, users[0].ID)
+}
+
+func (s *UserRepositorySQLiteSuite) Test_RolePermissions() {
+	user := s.createUser("sedin@mop.ba", "BA")
+	roles := &roleRepository{Store: s.DB}
+
+	admin, err := roles.GetByName(context.Background(), "admin")
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), roles.AssignToUser(context.Background(), user.ID, admin.ID))
+	require.NoError(s.T(), roles.AssignToUser(context.Background(), user.ID, admin.ID))
+
+	permissions, err := roles.GetPermissionNamesByUserID(context.Background(), user.ID)
+
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{"*"}, permissions)
+}
+
+func (s *UserRepositorySQLiteSuite) Test_RefreshTokenRotate() {
+	user := s.createUser("sedin@mop.ba", "BA")
+	tokens := &refreshTokenRepository{Store: s.DB}
+
+	current := &models.RefreshToken{UserID: user.ID, TokenHash: "current", ExpiresAt: time.Now().Add(time.Hour)}
+	next := &models.RefreshToken{UserID: user.ID, TokenHash: "next", ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(s.T(), tokens.Create(context.Background(), current))
+	require.NoError(s.T(), tokens.Create(context.Background(), next))
+
+	// concurrent request holds its own copy of the same token
+	stale := *current
+
+	rotated, err := tokens.Rotate(context.Background(), current, next.ID)
+	require.NoError(s.T(), err)
+	assert.True(s.T(), rotated)
+	assert.NotNil(s.T(), current.RevokedAt)
+
+	rotated, err = tokens.Rotate(context.Background(), &stale, next.ID)
+	require.NoError(s.T(), err)
+	assert.False(s.T(), rotated)
+
+	stored, err := tokens.GetByTokenHash(context.Background(), "current")
+	require.NoError(s.T(), err)
+	require.NotNil(s.T(), stored.ReplacedByID)
+	assert.Equal(s.T(), next.ID, *stored.ReplacedByID)
+}
+
+func (s *UserRepositorySQLiteSuite) Test_OneTimeCodeIncrementAttempts() {
+	user := s.createUser("sedin@mop.ba", "BA")
+	codes := &oneTimeCodeRepository{Store: s.DB}
+
+	code := &models.OneTimeCode{UserID: user.ID, Purpose: models.OneTimeCodePurposePhoneVerification, CodeHash: "hash", ExpiresAt: time.Now().Add(time.Minute)}
+	require.NoError(s.T(), codes.Create(context.Background(), code))
+
+	// stale copy read before other attempts were counted
+	stale := *code
+
+	for i := 0; i < 2; i++ {
+		counted, err := codes.IncrementAttempts(context.Background(), code, 2)
+		require.NoError(s.T(), err)
+		assert.True(s.T(), counted, "expected attempt %d to be counted", i+1)
+	}
+
+	counted, err := codes.IncrementAttempts(context.Background(), &stale, 2)
+	require.NoError(s.T(), err)
+	assert.False(s.T(), counted, "expected attempt over limit not to be counted")
+
+	latest, err := codes.GetLatestByUserID(context.Background(), user.ID, models.OneTimeCodePurposePhoneVerification)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 2, latest.Attempts)
+}
@@ -1,16 +1,22 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"net/url"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-flow/template-api/db"
+	apperrors "github.com/go-flow/template-api/domain/errors"
 	"github.com/go-flow/template-api/domain/models"
 	"github.com/go-flow/template-api/pkg/paging"
+	"github.com/go-flow/template-api/pkg/search"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/jinzhu/gorm"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,7 +32,7 @@ type UserRepositorySuite struct {
 	repository UserRepository
 }
 
-//TestUserRepositorySuite is ure repository test suite runner
+// TestUserRepositorySuite is ure repository test suite runner
 func TestUserRepositorySuite(t *testing.T) {
 	suite.Run(t, new(UserRepositorySuite))
 }
@@ -66,7 +72,7 @@ func (s *UserRepositorySuite) Test_GetByID() {
 	s.mock.ExpectQuery(regexp.QuoteMeta(query)).
 		WillReturnRows(rows).WithArgs(1)
 
-	user, err := s.repository.GetByID(1)
+	user, err := s.repository.GetByID(context.Background(), 1)
 
 	if err != nil {
 		s.Errorf(err, "ups got error")
@@ -97,6 +103,22 @@ func (s *UserRepositorySuite) Test_GetByID() {
 	assert.Nil(s.T(), user.DeletedAt, "Expected user.DeletedAt to be `nil` got %v", user.DeletedAt)
 }
 
+func (s *UserRepositorySuite) Test_GetByID_Deadline() {
+	query := "SELECT * FROM `users` WHERE `users`.`deleted_at` IS NULL AND ((id = ?)) ORDER BY `users`.`id` ASC LIMIT 1"
+	s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1).
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := s.repository.GetByID(ctx, 1)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), context.DeadlineExceeded, ctx.Err())
+}
+
 func (s *UserRepositorySuite) Test_GetByEmail() {
 
 	insertTime := time.Now()
@@ -107,7 +129,7 @@ func (s *UserRepositorySuite) Test_GetByEmail() {
 	s.mock.ExpectQuery(regexp.QuoteMeta(query)).
 		WillReturnRows(rows).WithArgs("sedin@mop.ba")
 
-	user, err := s.repository.GetByEmail("sedin@mop.ba")
+	user, err := s.repository.GetByEmail(context.Background(), "sedin@mop.ba")
 
 	if err != nil {
 		s.Errorf(err, "ups got error")
@@ -161,7 +183,7 @@ func (s *UserRepositorySuite) Test_GetAll() {
 		WillReturnRows(countRow)
 
 	paginator := paging.NewWithDefaults()
-	users, err := s.repository.GetAll(paginator)
+	users, err := s.repository.GetAll(context.Background(), paginator)
 	if err != nil {
 		s.Errorf(err, "Unable to fetch users")
 		return
@@ -197,6 +219,153 @@ func (s *UserRepositorySuite) Test_GetAll() {
 	assert.Nil(s.T(), user.DeletedAt, "Expected user.DeletedAt to be `nil` got %v", user.DeletedAt)
 }
 
+func (s *UserRepositorySuite) Test_GetAll_OnlyDeleted() {
+
+	deleteTime := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "email", "deleted_at"}).
+		AddRow(1, "sedin@mop.ba", deleteTime)
+
+	// mock Select query
+	query := "SELECT * FROM `users` WHERE (deleted_at IS NOT NULL) ORDER BY id ASC LIMIT 20 OFFSET 0"
+	s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WillReturnRows(rows)
+
+	// mock count query
+	query = "SELECT count(*) FROM `users` WHERE (deleted_at IS NOT NULL)"
+	s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WillReturnRows(sqlmock.NewRows([]string{"total_entries_size"}).AddRow(1))
+
+	paginator := paging.NewWithDefaults()
+	paginator.OnlyDeleted = true
+
+	users, err := s.repository.GetAll(context.Background(), paginator)
+
+	assert.Nil(s.T(), err, "Expected error to be <nil>, got %v", err)
+	assert.Len(s.T(), users, 1, "Expected one deleted user, got %v", len(users))
+	assert.NotNil(s.T(), users[0].DeletedAt, "Expected user.DeletedAt to be set")
+}
+
+func (s *UserRepositorySuite) Test_GetAll_Filters() {
+
+	rows := sqlmock.NewRows([]string{"id", "email", "country"}).
+		AddRow(1, "sedin@mop.ba", "DE")
+
+	// mock Select query
+	query := "SELECT * FROM `users` WHERE `users`.`deleted_at` IS NULL AND ((country = ? AND id IN (?,?))) ORDER BY id ASC LIMIT 20 OFFSET 0"
+	s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("DE", 1, 2).
+		WillReturnRows(rows)
+
+	// mock count query
+	query = "SELECT count(*) FROM `users` WHERE `users`.`deleted_at` IS NULL AND ((country = ? AND id IN (?,?)))"
+	s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("DE", 1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"total_entries_size"}).AddRow(1))
+
+	paginator := paging.NewPaginatorFromParams(url.Values{"filter[country]": {"DE"}, "filter[id][in]": {"1,2"}})
+	users, err := s.repository.GetAll(context.Background(), paginator)
+
+	assert.Nil(s.T(), err, "Expected error to be <nil>, got %v", err)
+	assert.Len(s.T(), users, 1, "Expected one user, got %v", len(users))
+}
+
+func (s *UserRepositorySuite) Test_GetAll_InvalidFilter() {
+	paginator := paging.NewPaginatorFromParams(url.Values{"filter[password_hash]": {"x"}})
+
+	_, err := s.repository.GetAll(context.Background(), paginator)
+
+	assert.IsType(s.T(), &paging.FilterError{}, err, "Expected filter error, got %v", err)
+}
+
+func (s *UserRepositorySuite) Test_GetAll_Fields() {
+
+	rows := sqlmock.NewRows([]string{"id", "email"}).
+		AddRow(1, "sedin@mop.ba")
+
+	// mock Select query
+	query := "SELECT id, email FROM `users` WHERE `users`.`deleted_at` IS NULL ORDER BY id ASC LIMIT 20 OFFSET 0"
+	s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WillReturnRows(rows)
+
+	// mock count query
+	query = "SELECT count(*) FROM `users` WHERE `users`.`deleted_at` IS NULL"
+	s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WillReturnRows(sqlmock.NewRows([]string{"total_entries_size"}).AddRow(1))
+
+	paginator := paging.NewPaginatorFromParams(url.Values{})
+	paginator.Fields = paging.Fields{"email"}
+
+	users, err := s.repository.GetAll(context.Background(), paginator)
+
+	assert.Nil(s.T(), err, "Expected error to be <nil>, got %v", err)
+	assert.Len(s.T(), users, 1, "Expected one user, got %v", len(users))
+	assert.Equal(s.T(), 1, paginator.TotalEntriesSize, "Expected total entries size to be 1, got %v", paginator.TotalEntriesSize)
+}
+
+func (s *UserRepositorySuite) Test_GetByIDWithFields() {
+	rows := sqlmock.NewRows([]string{"id", "email", "first_name"}).
+		AddRow(1, "sedin@mop.ba", "Sedin")
+
+	query := "SELECT id, email, first_name FROM `users` WHERE `users`.`deleted_at` IS NULL AND ((id = ?)) ORDER BY `users`.`id` ASC LIMIT 1"
+	s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	user, err := s.repository.GetByIDWithFields(context.Background(), 1, paging.Fields{"email", "first_name"})
+
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "Sedin", user.FirstName)
+	assert.Equal(s.T(), "", user.LastName, "Expected last name not to be loaded")
+}
+
+func (s *UserRepositorySuite) Test_GetAll_Cursor() {
+	codec := paging.NewCursorCodec("secret")
+	repository := &userRepository{Store: s.DB, cursors: codec}
+
+	cursor, _ := codec.Encode(&paging.Cursor{Sort: "id", Values: []interface{}{1}})
+
+	rows := sqlmock.NewRows([]string{"id", "email"}).
+		AddRow(2, "sedin+1@mop.ba").
+		AddRow(3, "sedin+2@mop.ba").
+		AddRow(4, "sedin+3@mop.ba")
+
+	// mock Select query, one row more than page size is fetched
+	query := "SELECT * FROM `users` WHERE `users`.`deleted_at` IS NULL AND (((id > ?))) ORDER BY id ASC LIMIT 3"
+	s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("1").
+		WillReturnRows(rows)
+
+	paginator := paging.NewPaginatorFromParams(url.Values{"per_page": {"2"}, "cursor": {cursor}})
+	users, err := repository.GetAll(context.Background(), paginator)
+
+	assert.Nil(s.T(), err, "Expected error to be <nil>, got %v", err)
+	assert.Len(s.T(), users, 2, "Expected page of 2 users, got %v", len(users))
+	assert.Equal(s.T(), uint64(2), users[0].ID, "Expected first user.ID to be 2, got `%v`", users[0].ID)
+	assert.NotEmpty(s.T(), paginator.NextCursor, "Expected next cursor")
+	assert.NotEmpty(s.T(), paginator.PrevCursor, "Expected previous cursor")
+}
+
+func (s *UserRepositorySuite) Test_GetAllByInvitedByUserIDs() {
+
+	insertTime := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "email", "invited_by_user_id", "created_at", "updated_at", "deleted_at"}).
+		AddRow(2, "Sedin", "Dugum", "sedin+1@mop.ba", 1, insertTime, insertTime, nil).
+		AddRow(3, "Sedin", "Dugum", "sedin+2@mop.ba", 1, insertTime, insertTime, nil)
+
+	query := "SELECT * FROM `users` WHERE `users`.`deleted_at` IS NULL AND ((invited_by_user_id IN (?))) ORDER BY id ASC"
+	s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WillReturnRows(rows).WithArgs(1)
+
+	users, err := s.repository.GetAllByInvitedByUserIDs(context.Background(), []uint64{1})
+	if err != nil {
+		s.Errorf(err, "Unable to fetch invitees")
+		return
+	}
+
+	assert.Len(s.T(), users, 2, "Expected 2 invitees, got %v", len(users))
+	assert.Equal(s.T(), uint64(1), *users[0].InvitedByUserID, "Expected user.InvitedByUserID to be 1, got `%v`", *users[0].InvitedByUserID)
+}
+
 func (s *UserRepositorySuite) Test_Create() {
 	insertTime := time.Now()
 	// prepare model
@@ -210,13 +379,14 @@ func (s *UserRepositorySuite) Test_Create() {
 
 	// mock query
 	s.mock.ExpectBegin()
-	query := "INSERT INTO `users` (`first_name`,`last_name`,`profile_image`,`email`,`is_email_verified`,`bio`,`phone_number`,`is_phone_verified`,`country`,`state`,`area`,`city`,`address`,`post_code`,`birth_date`,`tos_accepted`,`invited_by_user_id`,`is_active`,`created_at`,`updated_at`,`deleted_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
+	query := "INSERT INTO `users` (`first_name`,`last_name`,`profile_image`,`email`,`password_hash`,`is_email_verified`,`bio`,`phone_number`,`is_phone_verified`,`country`,`state`,`area`,`city`,`address`,`post_code`,`birth_date`,`tos_accepted`,`invited_by_user_id`,`is_active`,`suspended_at`,`suspended_until`,`suspended_reason`,`created_at`,`updated_at`,`deleted_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
 	s.mock.ExpectExec(regexp.QuoteMeta(query)).
 		WithArgs(
 			user.FirstName,
 			user.LastName,
 			user.ProfileImage,
 			user.Email,
+			user.PasswordHash,
 			user.IsEmailVerified,
 			user.Bio,
 			user.PhoneNumber,
@@ -231,13 +401,16 @@ func (s *UserRepositorySuite) Test_Create() {
 			user.TosAccepted,
 			user.InvitedByUserID,
 			user.IsActive,
+			user.SuspendedAt,
+			user.SuspendedUntil,
+			user.SuspendedReason,
 			user.CreatedAt,
 			user.UpdatedAt,
 			user.DeletedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	s.mock.ExpectCommit()
 
-	err := s.repository.Save(user)
+	err := s.repository.Save(context.Background(), user)
 	if err != nil {
 		s.Errorf(err, "unable to create user")
 	}
@@ -266,6 +439,35 @@ func (s *UserRepositorySuite) Test_Create() {
 	assert.Nil(s.T(), user.DeletedAt, "Expected user.DeletedAt to be `nil` got %v", user.DeletedAt)
 }
 
+func (s *UserRepositorySuite) Test_Create_DuplicateEmail() {
+	user := &models.User{Email: "sedin@mop.ba"}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users`")).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'sedin@mop.ba' for key 'users_email_idx'"})
+	s.mock.ExpectRollback()
+
+	err := s.repository.Create(context.Background(), user)
+
+	assert.True(s.T(), errors.Is(err, ErrDuplicateRecord), "Expected duplicate record error, got %v", err)
+	assert.Equal(s.T(), apperrors.KindConflict, apperrors.KindOf(err))
+}
+
+func (s *UserRepositorySuite) Test_Purge_Referenced() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `users` WHERE `users`.`id` = ?")).
+		WithArgs(1).
+		WillReturnError(&mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row: a foreign key constraint fails"})
+	s.mock.ExpectRollback()
+
+	err := s.repository.Purge(context.Background(), 1)
+
+	assert.True(s.T(), errors.Is(err, ErrRecordReferenced), "Expected record referenced error, got %v", err)
+
+	var merr *mysql.MySQLError
+	assert.True(s.T(), errors.As(err, &merr), "Expected database error to be kept as underlying error")
+}
+
 func (s *UserRepositorySuite) Test_Update() {
 	// prepare model
 	user := &models.User{
@@ -280,13 +482,14 @@ func (s *UserRepositorySuite) Test_Update() {
 
 	// mock query
 	s.mock.ExpectBegin()
-	query := "UPDATE `users` SET `first_name` = ?, `last_name` = ?, `profile_image` = ?, `email` = ?, `is_email_verified` = ?, `bio` = ?, `phone_number` = ?, `is_phone_verified` = ?, `country` = ?, `state` = ?, `area` = ?, `city` = ?, `address` = ?, `post_code` = ?, `birth_date` = ?, `tos_accepted` = ?, `invited_by_user_id` = ?, `is_active` = ?, `created_at` = ?, `updated_at` = ?, `deleted_at` = ? WHERE `users`.`deleted_at` IS NULL AND `users`.`id` = ?"
+	query := "UPDATE `users` SET `first_name` = ?, `last_name` = ?, `profile_image` = ?, `email` = ?, `password_hash` = ?, `is_email_verified` = ?, `bio` = ?, `phone_number` = ?, `is_phone_verified` = ?, `country` = ?, `state` = ?, `area` = ?, `city` = ?, `address` = ?, `post_code` = ?, `birth_date` = ?, `tos_accepted` = ?, `invited_by_user_id` = ?, `is_active` = ?, `suspended_at` = ?, `suspended_until` = ?, `suspended_reason` = ?, `created_at` = ?, `updated_at` = ?, `deleted_at` = ? WHERE `users`.`deleted_at` IS NULL AND `users`.`id` = ?"
 	s.mock.ExpectExec(regexp.QuoteMeta(query)).
 		WithArgs(
 			user.FirstName,
 			user.LastName,
 			user.ProfileImage,
 			user.Email,
+			user.PasswordHash,
 			user.IsEmailVerified,
 			user.Bio,
 			user.PhoneNumber,
@@ -301,6 +504,9 @@ func (s *UserRepositorySuite) Test_Update() {
 			user.TosAccepted,
 			user.InvitedByUserID,
 			user.IsActive,
+			user.SuspendedAt,
+			user.SuspendedUntil,
+			user.SuspendedReason,
 			AnyTime{},
 			AnyTime{},
 			user.DeletedAt,
@@ -308,7 +514,7 @@ func (s *UserRepositorySuite) Test_Update() {
 		WillReturnResult(sqlmock.NewResult(0, 1))
 	s.mock.ExpectCommit()
 
-	err := s.repository.Save(user)
+	err := s.repository.Save(context.Background(), user)
 	if err != nil {
 		s.Errorf(err, "unable to update user")
 	}
@@ -351,15 +557,179 @@ func (s *UserRepositorySuite) Test_Delete() {
 
 	// mock query
 	s.mock.ExpectBegin()
-	query := "DELETE FROM `users` WHERE `users`.`id` = ?"
+	query := "UPDATE `users` SET `deleted_at`=? WHERE `users`.`deleted_at` IS NULL AND `users`.`id` = ?"
 	s.mock.ExpectExec(regexp.QuoteMeta(query)).
 		WithArgs(
+			AnyTime{},
 			user.ID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 	s.mock.ExpectCommit()
 
-	err := s.repository.Delete(user)
+	err := s.repository.Delete(context.Background(), user)
 	if err != nil {
-		s.Errorf(err, "unable to create user")
+		s.Errorf(err, "unable to delete user")
 	}
 }
+
+func (s *UserRepositorySuite) Test_Restore() {
+	// mock query
+	s.mock.ExpectBegin()
+	query := "UPDATE `users` SET `deleted_at` = ?, `updated_at` = ? WHERE (id = ? AND deleted_at IS NOT NULL)"
+	s.mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(nil, AnyTime{}, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+
+	err := s.repository.Restore(context.Background(), 1)
+
+	assert.Nil(s.T(), err, "Expected error to be <nil>, got %v", err)
+}
+
+func (s *UserRepositorySuite) Test_Restore_NotDeleted() {
+	// mock query
+	s.mock.ExpectBegin()
+	query := "UPDATE `users` SET `deleted_at` = ?, `updated_at` = ? WHERE (id = ? AND deleted_at IS NOT NULL)"
+	s.mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(nil, AnyTime{}, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mock.ExpectCommit()
+
+	err := s.repository.Restore(context.Background(), 1)
+
+	assert.True(s.T(), gorm.IsRecordNotFoundError(err), "Expected record not found error, got %v", err)
+}
+
+func (s *UserRepositorySuite) Test_Purge() {
+	// mock query
+	s.mock.ExpectBegin()
+	query := "DELETE FROM `users` WHERE `users`.`id` = ?"
+	s.mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+
+	err := s.repository.Purge(context.Background(), 1)
+
+	assert.Nil(s.T(), err, "Expected error to be <nil>, got %v", err)
+}
+
+func (s *UserRepositorySuite) Test_PurgeDeletedBefore() {
+	before := time.Now().Add(-24 * time.Hour)
+
+	// mock query
+	s.mock.ExpectBegin()
+	query := "DELETE FROM `users` WHERE (deleted_at IS NOT NULL AND deleted_at < ?)"
+	s.mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	s.mock.ExpectCommit()
+
+	purged, err := s.repository.PurgeDeletedBefore(context.Background(), before)
+
+	assert.Nil(s.T(), err, "Expected error to be <nil>, got %v", err)
+	assert.Equal(s.T(), int64(3), purged, "Expected 3 purged users, got %v", purged)
+}
+
+func (s *UserRepositorySuite) Test_Search_MySQL() {
+	index := &mysqlSearchIndex{Store: s.DB}
+
+	rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "email", "city", "bio", "score"}).
+		AddRow(2, "Sedin", "Dugum", "sedin@mop.ba", "Sarajevo", "Go developer", 1.75)
+
+	match := "MATCH (first_name,last_name,email,city,bio) AGAINST (? IN NATURAL LANGUAGE MODE)"
+	query := "SELECT users.*, " + match + " AS score FROM `users` WHERE (deleted_at IS NULL) AND (" + match + ") ORDER BY score DESC,id ASC LIMIT 20"
+	s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("sarajevo", "sarajevo").
+		WillReturnRows(rows)
+
+	results, err := index.Search(context.Background(), "sarajevo", 20, true)
+
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 1)
+	assert.Equal(s.T(), uint64(2), results[0].User.ID)
+	assert.Equal(s.T(), 1.75, results[0].Score)
+	assert.Equal(s.T(), map[string]string{"city": "<mark>Sarajevo</mark>"}, results[0].Highlights)
+}
+
+func (s *UserRepositorySuite) Test_Search_MySQLPublic() {
+	index := &mysqlSearchIndex{Store: s.DB}
+
+	rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "email", "city", "bio", "score"}).
+		AddRow(2, "Sedin", "Dugum", "sedin@mop.ba", "Sarajevo", "Go developer", 1.75)
+
+	// email is not searched for viewers who cannot see it
+	match := "MATCH (first_name,last_name,city,bio) AGAINST (? IN NATURAL LANGUAGE MODE)"
+	query := "SELECT users.*, " + match + " AS score FROM `users` WHERE (deleted_at IS NULL) AND (" + match + ") ORDER BY score DESC,id ASC LIMIT 20"
+	s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("sedin", "sedin").
+		WillReturnRows(rows)
+
+	results, err := index.Search(context.Background(), "sedin", 20, false)
+
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 1)
+	assert.Equal(s.T(), map[string]string{"first_name": "<mark>Sedin</mark>"}, results[0].Highlights)
+}
+
+func (s *UserRepositorySuite) Test_Search_Memory() {
+	index := &memorySearchIndex{Store: s.DB, index: search.NewInvertedIndex(), public: search.NewInvertedIndex()}
+
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`deleted_at` IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "email", "city", "bio"}).
+			AddRow(1, "Sedin", "Dugum", "sedin@mop.ba", "Sarajevo", "").
+			AddRow(2, "Amer", "Hodzic", "amer@mop.ba", "Mostar", "Lives near Sarajevo"))
+
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`deleted_at` IS NULL AND ((id IN (?,?)))")).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "email", "city", "bio"}).
+			AddRow(2, "Amer", "Hodzic", "amer@mop.ba", "Mostar", "Lives near Sarajevo").
+			AddRow(1, "Sedin", "Dugum", "sedin@mop.ba", "Sarajevo", ""))
+
+	results, err := index.Search(context.Background(), "sarajevo", 20, true)
+
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 2)
+	assert.Equal(s.T(), uint64(1), results[0].User.ID, "expected shorter document to rank first")
+	assert.Equal(s.T(), uint64(2), results[1].User.ID)
+	assert.Equal(s.T(), "Lives near <mark>Sarajevo</mark>", results[1].Highlights["bio"])
+
+	// indexed changes are visible without reloading from database
+	require.NoError(s.T(), index.Remove(context.Background(), 1))
+	require.NoError(s.T(), index.Index(context.Background(), &models.User{ID: 3, FirstName: "Sarajevo"}))
+
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`deleted_at` IS NULL AND ((id IN (?,?)))")).
+		WithArgs(3, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name"}).
+			AddRow(2, "Amer").
+			AddRow(3, "Sarajevo"))
+
+	results, err = index.Search(context.Background(), "sarajevo", 20, true)
+
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 2)
+	assert.Equal(s.T(), uint64(3), results[0].User.ID)
+}
+
+func (s *UserRepositorySuite) Test_Search_MemoryPublic() {
+	index := &memorySearchIndex{Store: s.DB, index: search.NewInvertedIndex(), public: search.NewInvertedIndex()}
+
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`deleted_at` IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "email", "city", "bio"}).
+			AddRow(1, "Sedin", "Dugum", "hidden@mop.ba", "Sarajevo", ""))
+
+	// email is not searched for viewers who cannot see it
+	results, err := index.Search(context.Background(), "hidden", 20, false)
+
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), results)
+
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`deleted_at` IS NULL AND ((id IN (?)))")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "hidden@mop.ba"))
+
+	results, err = index.Search(context.Background(), "hidden", 20, true)
+
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 1)
+	assert.Equal(s.T(), "<mark>hidden</mark>@mop.ba", results[0].Highlights["email"])
+}
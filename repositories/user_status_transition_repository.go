@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+)
+
+// UserStatusTransitionRepository defines set of available operations around UserStatusTransition records
+type UserStatusTransitionRepository interface {
+	// UserStatusTransitionRepository ensures interface implementation
+	UserStatusTransitionRepository() string
+
+	// GetAllByUserID returns status transitions of given user, newest first
+	GetAllByUserID(ctx context.Context, userID uint64) ([]*models.UserStatusTransition, error)
+
+	// Create new UserStatusTransition record in database
+	Create(ctx context.Context, transition *models.UserStatusTransition) error
+}
+
+// NewUserStatusTransitionRepository creates UserStatusTransitionRepository interface implementation
+func NewUserStatusTransitionRepository(app *flow.App) UserStatusTransitionRepository {
+	return &userStatusTransitionRepository{}
+}
+
+// userStatusTransitionRepository struct that implements UserStatusTransitionRepository interface
+type userStatusTransitionRepository struct {
+	Store db.Store
+}
+
+// UserStatusTransitionRepository ensures interface implementation
+func (repo *userStatusTransitionRepository) UserStatusTransitionRepository() string {
+	return "userStatusTransitionRepository"
+}
+
+// GetAllByUserID returns status transitions of given user, newest first
+func (repo *userStatusTransitionRepository) GetAllByUserID(ctx context.Context, userID uint64) ([]*models.UserStatusTransition, error) {
+	model := make([]*models.UserStatusTransition, 0)
+
+	tx := db.WithContext(ctx, repo.Store).Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Order("id DESC").
+		Find(&model)
+
+	return model, tx.Error
+}
+
+// Create new UserStatusTransition record in database
+func (repo *userStatusTransitionRepository) Create(ctx context.Context, transition *models.UserStatusTransition) error {
+	tx := db.WithContext(ctx, repo.Store).Create(transition)
+	return translateError(tx.Error)
+}
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+)
+
+// UserTokenRepository defines set of available operations around UserToken records
+type UserTokenRepository interface {
+	// UserTokenRepository ensures interface implementation
+	UserTokenRepository() string
+
+	// GetByTokenHash returns user token record based on a provided purpose and token hash
+	GetByTokenHash(ctx context.Context, purpose string, tokenHash string) (*models.UserToken, error)
+
+	// GetLatestByUserID returns most recently created user token for given purpose
+	GetLatestByUserID(ctx context.Context, userID uint64, purpose string) (*models.UserToken, error)
+
+	// Create new UserToken record in database
+	Create(ctx context.Context, token *models.UserToken) error
+
+	// MarkUsed marks user token as used, it reports false when token was already used
+	MarkUsed(ctx context.Context, token *models.UserToken) (bool, error)
+
+	// InvalidateAllByUserID marks all unused user tokens for given purpose as used
+	InvalidateAllByUserID(ctx context.Context, userID uint64, purpose string) error
+}
+
+// NewUserTokenRepository creates UserTokenRepository interface implementation
+func NewUserTokenRepository(app *flow.App) UserTokenRepository {
+	return &userTokenRepository{}
+}
+
+// userTokenRepository struct that implements UserTokenRepository interface
+type userTokenRepository struct {
+	Store db.Store
+}
+
+// UserTokenRepository ensures interface implementation
+func (repo *userTokenRepository) UserTokenRepository() string {
+	return "userTokenRepository"
+}
+
+// GetByTokenHash returns user token record based on a provided purpose and token hash
+func (repo *userTokenRepository) GetByTokenHash(ctx context.Context, purpose string, tokenHash string) (*models.UserToken, error) {
+	model := new(models.UserToken)
+
+	tx := db.WithContext(ctx, repo.Store).Where("purpose = ? AND token_hash = ?", purpose, tokenHash).First(model)
+
+	return model, tx.Error
+}
+
+// GetLatestByUserID returns most recently created user token for given purpose
+func (repo *userTokenRepository) GetLatestByUserID(ctx context.Context, userID uint64, purpose string) (*models.UserToken, error) {
+	model := new(models.UserToken)
+
+	tx := db.WithContext(ctx, repo.Store).Where("user_id = ? AND purpose = ?", userID, purpose).
+		Order("created_at DESC").
+		Order("id DESC").
+		First(model)
+
+	return model, tx.Error
+}
+
+// Create new UserToken record in database
+func (repo *userTokenRepository) Create(ctx context.Context, token *models.UserToken) error {
+	tx := db.WithContext(ctx, repo.Store).Create(token)
+	return translateError(tx.Error)
+}
+
+// MarkUsed marks user token as used, it reports false when token was already used
+//
+// update is conditional so concurrent requests cannot consume the same token twice
+func (repo *userTokenRepository) MarkUsed(ctx context.Context, token *models.UserToken) (bool, error) {
+	now := time.Now()
+
+	tx := db.WithContext(ctx, repo.Store).Model(token).Where("used_at IS NULL").Update("used_at", now)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return false, nil
+	}
+
+	token.UsedAt = &now
+	return true, nil
+}
+
+// InvalidateAllByUserID marks all unused user tokens for given purpose as used
+func (repo *userTokenRepository) InvalidateAllByUserID(ctx context.Context, userID uint64, purpose string) error {
+	tx := db.WithContext(ctx, repo.Store).Model(&models.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now())
+	return tx.Error
+}
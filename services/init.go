@@ -5,4 +5,15 @@ import "github.com/go-flow/flow"
 // Init initializes services layer
 func Init(app *flow.App) {
 	app.Register(NewUserService(app))
+	app.Register(NewRefreshTokenService(app))
+	app.Register(NewPermissionService(app))
+	app.Register(NewUserTokenService(app))
+	app.Register(NewMailService(app))
+	app.Register(NewOneTimeCodeService(app))
+	app.Register(NewSmsService(app))
+	app.Register(NewTwoFactorService(app))
+	app.Register(NewInvitationService(app))
+	app.Register(NewTosService(app))
+	app.Register(NewUserStatusTransitionService(app))
+	app.Register(NewLocaleService(app))
 }
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	apperrors "github.com/go-flow/template-api/domain/errors"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/pkg/token"
+	"github.com/go-flow/template-api/repositories"
+	"github.com/jinzhu/gorm"
+)
+
+var (
+	// ErrInvalidInvitation is returned when invitation token is unknown, expired, accepted or revoked
+	ErrInvalidInvitation = apperrors.Validation(apperrors.CodeInvalidInvitation, "invalid or expired invitation")
+
+	// ErrInvitationNotPending is returned when changing invitation which was already accepted or revoked
+	ErrInvitationNotPending = apperrors.Conflict(apperrors.CodeInvitationNotPending, "invitation is no longer pending")
+)
+
+// InvitationService defines set of available operations around Invitation model
+type InvitationService interface {
+	// InvitationService ensures interface implementation
+	InvitationService() string
+
+	// GetByID returns invitation model based on a provided id
+	GetByID(ctx context.Context, id uint64) (*models.Invitation, error)
+
+	// GetByToken returns acceptable invitation for given plain text token
+	GetByToken(ctx context.Context, plainToken string) (*models.Invitation, error)
+
+	// GetAllByInviter returns all invitations sent by given user
+	GetAllByInviter(ctx context.Context, inviterUserID uint64) ([]*models.Invitation, error)
+
+	// HasPending reports whether there is pending, not expired invitation for given email
+	HasPending(ctx context.Context, email string) (bool, error)
+
+	// Create new invitation for given email
+	//
+	// plain text token is returned only once, database holds its hash
+	Create(ctx context.Context, inviterUserID uint64, email string, ttl time.Duration) (string, *models.Invitation, error)
+
+	// Accept marks invitation as accepted by given user
+	Accept(ctx context.Context, invitation *models.Invitation, userID uint64) error
+
+	// Revoke marks invitation as revoked
+	Revoke(ctx context.Context, invitation *models.Invitation) error
+}
+
+// NewInvitationService creates new InvitationService implementation
+func NewInvitationService(app *flow.App) InvitationService {
+	return &invitationService{}
+}
+
+// invitationService struct that implements InvitationService interface
+type invitationService struct {
+	// InvitationRepository implementation injected by dependency injection
+	InvitationRepository repositories.InvitationRepository
+}
+
+// InvitationService ensures interface implementation
+func (svc *invitationService) InvitationService() string {
+	return "invitationService"
+}
+
+// GetByID returns invitation model based on a provided id
+func (svc *invitationService) GetByID(ctx context.Context, id uint64) (*models.Invitation, error) {
+	return svc.InvitationRepository.GetByID(ctx, id)
+}
+
+// GetByToken returns acceptable invitation for given plain text token
+func (svc *invitationService) GetByToken(ctx context.Context, plainToken string) (*models.Invitation, error) {
+	model, err := svc.InvitationRepository.GetByTokenHash(ctx, token.Hash(plainToken))
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, ErrInvalidInvitation
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !model.IsAcceptable(time.Now()) {
+		return nil, ErrInvalidInvitation
+	}
+
+	return model, nil
+}
+
+// GetAllByInviter returns all invitations sent by given user
+func (svc *invitationService) GetAllByInviter(ctx context.Context, inviterUserID uint64) ([]*models.Invitation, error) {
+	return svc.InvitationRepository.GetAllByInviterUserID(ctx, inviterUserID)
+}
+
+// HasPending reports whether there is pending, not expired invitation for given email
+func (svc *invitationService) HasPending(ctx context.Context, email string) (bool, error) {
+	count, err := svc.InvitationRepository.CountPendingByEmail(ctx, email)
+	return count > 0, err
+}
+
+// Create new invitation for given email
+func (svc *invitationService) Create(ctx context.Context, inviterUserID uint64, email string, ttl time.Duration) (string, *models.Invitation, error) {
+	plain, err := token.Random(userTokenSize)
+	if err != nil {
+		return "", nil, err
+	}
+
+	model := &models.Invitation{
+		InviterUserID: inviterUserID,
+		Email:         email,
+		TokenHash:     token.Hash(plain),
+		Status:        models.InvitationStatusPending,
+		ExpiresAt:     time.Now().Add(ttl),
+	}
+
+	if err := svc.InvitationRepository.Create(ctx, model); err != nil {
+		return "", nil, err
+	}
+
+	return plain, model, nil
+}
+
+// Accept marks invitation as accepted by given user
+func (svc *invitationService) Accept(ctx context.Context, invitation *models.Invitation, userID uint64) error {
+	invitation.AcceptedUserID = &userID
+
+	return svc.updateStatus(ctx, invitation, models.InvitationStatusAccepted)
+}
+
+// Revoke marks invitation as revoked
+func (svc *invitationService) Revoke(ctx context.Context, invitation *models.Invitation) error {
+	return svc.updateStatus(ctx, invitation, models.InvitationStatusRevoked)
+}
+
+// updateStatus changes status of pending invitation
+func (svc *invitationService) updateStatus(ctx context.Context, invitation *models.Invitation, status string) error {
+	updated, err := svc.InvitationRepository.UpdateStatus(ctx, invitation, status)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrInvitationNotPending
+	}
+
+	return nil
+}
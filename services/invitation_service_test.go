@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/mocks"
+	"github.com/go-flow/template-api/pkg/token"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type InvitationServiceSuite struct {
+	suite.Suite
+
+	ctx                      context.Context
+	service                  InvitationService
+	invitationRepositoryMock *mocks.InvitationRepositoryMock
+}
+
+// TestInvitationServiceSuite is invitation service test suite runner
+func TestInvitationServiceSuite(t *testing.T) {
+	suite.Run(t, new(InvitationServiceSuite))
+}
+
+// SetupSuite configures suite for unit testing
+func (s *InvitationServiceSuite) SetupSuite() {
+	s.ctx = db.WithRequestID(context.Background(), "request-id")
+}
+
+// BeforeTest is called before every test in InvitationServiceSuite
+func (s *InvitationServiceSuite) BeforeTest(_, _ string) {
+	s.invitationRepositoryMock = mocks.NewInvitationRepositoryMock()
+
+	s.service = &invitationService{
+		InvitationRepository: s.invitationRepositoryMock,
+	}
+}
+
+// AfterTest ensures that all InvitationServiceSuite expectations were met
+func (s *InvitationServiceSuite) AfterTest(_, _ string) {
+	s.invitationRepositoryMock.AssertExpectations(s.T())
+}
+
+func (s *InvitationServiceSuite) Test_Create() {
+	s.invitationRepositoryMock.On("Create", s.ctx, mock.AnythingOfType("*models.Invitation")).Return(nil)
+
+	plain, model, err := s.service.Create(s.ctx, 1, "john@example.com", time.Hour)
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Equal(s.T(), token.Hash(plain), model.TokenHash, "expected stored token to be hash of plain token")
+	assert.Equal(s.T(), models.InvitationStatusPending, model.Status, "expected invitation to be pending")
+	assert.True(s.T(), model.IsAcceptable(time.Now()), "expected created invitation to be acceptable")
+}
+
+func (s *InvitationServiceSuite) Test_GetByToken() {
+	now := time.Now()
+
+	pending := &models.Invitation{ID: 1, Status: models.InvitationStatusPending, ExpiresAt: now.Add(time.Hour)}
+	expired := &models.Invitation{ID: 2, Status: models.InvitationStatusPending, ExpiresAt: now.Add(-time.Hour)}
+	revoked := &models.Invitation{ID: 3, Status: models.InvitationStatusRevoked, ExpiresAt: now.Add(time.Hour), RevokedAt: &now}
+
+	s.invitationRepositoryMock.On("GetByTokenHash", s.ctx, token.Hash("pending")).Return(pending, nil)
+	s.invitationRepositoryMock.On("GetByTokenHash", s.ctx, token.Hash("expired")).Return(expired, nil)
+	s.invitationRepositoryMock.On("GetByTokenHash", s.ctx, token.Hash("revoked")).Return(revoked, nil)
+	s.invitationRepositoryMock.On("GetByTokenHash", s.ctx, token.Hash("unknown")).Return(nil, gorm.ErrRecordNotFound)
+
+	model, err := s.service.GetByToken(s.ctx, "pending")
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Equal(s.T(), pending, model, "expected pending invitation to be returned")
+
+	for _, plain := range []string{"expired", "revoked", "unknown"} {
+		_, err = s.service.GetByToken(s.ctx, plain)
+		assert.Equal(s.T(), ErrInvalidInvitation, err, "expected `%s` invitation to be rejected, got %v", plain, err)
+	}
+}
+
+func (s *InvitationServiceSuite) Test_Accept() {
+	invitation := &models.Invitation{ID: 1, Status: models.InvitationStatusPending}
+	raced := &models.Invitation{ID: 2, Status: models.InvitationStatusPending}
+
+	s.invitationRepositoryMock.On("UpdateStatus", s.ctx, invitation, models.InvitationStatusAccepted).Return(true, nil)
+	s.invitationRepositoryMock.On("UpdateStatus", s.ctx, raced, models.InvitationStatusAccepted).Return(false, nil)
+
+	err := s.service.Accept(s.ctx, invitation, 5)
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Equal(s.T(), uint64(5), *invitation.AcceptedUserID, "expected accepting user to be recorded")
+
+	err = s.service.Accept(s.ctx, raced, 6)
+	assert.Equal(s.T(), ErrInvitationNotPending, err, "expected already changed invitation to be rejected, got %v", err)
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"strconv"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/flow/binding"
+	"github.com/go-flow/template-api/config"
+	"github.com/go-flow/template-api/pkg/i18n"
+	"github.com/go-playground/validator/v10"
+)
+
+// LocaleService negotiates response language and translates messages rendered to clients
+type LocaleService interface {
+	// LocaleService ensures interface implementation
+	LocaleService() string
+
+	// Negotiate returns supported locale best matching preferences,
+	// e.g. `app-language` and `Accept-Language` header values in priority order
+	Negotiate(preferences ...string) string
+
+	// ErrorMessage returns message of domain error code translated to locale
+	ErrorMessage(locale string, code int) (string, bool)
+
+	// ValidationMessage returns human readable message of field error translated to locale
+	//
+	// catalog message stored under `validation.<key>` overrides validator translation,
+	// key itself is returned when there is no translation
+	ValidationMessage(locale string, key string, fe validator.FieldError) string
+}
+
+// NewLocaleService creates new LocaleService implementation
+//
+// catalogs are loaded from LocalesPath, validator translations are
+// registered to request binding validator for every catalog locale which has them
+func NewLocaleService(app *flow.App) LocaleService {
+	cfg := app.AppConfig.(config.AppConfig)
+
+	catalog, err := i18n.LoadDir(cfg.LocalesPath)
+	if err != nil {
+		app.Logger.Fatalf("unable to load locales: %v", err)
+	}
+
+	var locales []string
+	for _, l := range append(catalog.Locales(), cfg.DefaultLanguage) {
+		if i18n.HasValidationLocale(l) {
+			locales = append(locales, l)
+		}
+	}
+
+	v, _ := binding.Validator.Engine().(*validator.Validate)
+	if v == nil {
+		v = validator.New()
+	}
+
+	translator, err := i18n.NewValidationTranslator(v, locales...)
+	if err != nil {
+		app.Logger.Fatalf("unable to register validation messages: %v", err)
+	}
+
+	return &localeService{
+		catalog:     catalog,
+		translator:  translator,
+		defaultLang: i18n.Normalize(cfg.DefaultLanguage),
+	}
+}
+
+// localeService struct that implements LocaleService interface
+type localeService struct {
+	catalog     *i18n.Catalog
+	translator  *i18n.ValidationTranslator
+	defaultLang string
+}
+
+// LocaleService ensures interface implementation
+func (svc *localeService) LocaleService() string {
+	return "localeService"
+}
+
+// Negotiate returns supported locale best matching preferences
+func (svc *localeService) Negotiate(preferences ...string) string {
+	return i18n.Negotiate(svc.catalog.Locales(), svc.defaultLang, preferences...)
+}
+
+// ErrorMessage returns message of domain error code translated to locale
+func (svc *localeService) ErrorMessage(locale string, code int) (string, bool) {
+	return svc.catalog.Lookup(i18n.Fallbacks(locale, svc.defaultLang), "errors."+strconv.Itoa(code))
+}
+
+// ValidationMessage returns human readable message of field error translated to locale
+func (svc *localeService) ValidationMessage(locale string, key string, fe validator.FieldError) string {
+	chain := i18n.Fallbacks(locale, svc.defaultLang)
+
+	if msg, ok := svc.catalog.Lookup(chain, "validation."+key); ok {
+		return msg
+	}
+
+	if msg, ok := svc.translator.Translate(chain, fe); ok {
+		return msg
+	}
+
+	return key
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/config"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/pkg/mailer"
+)
+
+// MailService defines set of emails application sends to users
+type MailService interface {
+	// MailService ensures interface implementation
+	MailService() string
+
+	// SendEmailVerification sends email address verification link to user
+	SendEmailVerification(user *models.User, plainToken string) error
+
+	// SendPasswordReset sends password reset link to user
+	SendPasswordReset(user *models.User, plainToken string) error
+
+	// SendInvitation sends invitation link to invited email address
+	SendInvitation(inviter *models.User, invitation *models.Invitation, plainToken string) error
+}
+
+// NewMailService creates new MailService implementation
+//
+// mailer implementation is selected by MailDriver configuration
+func NewMailService(app *flow.App) MailService {
+	cfg := app.AppConfig.(config.AppConfig)
+
+	m, err := mailer.New(cfg.MailDriver, cfg.SmtpHost, cfg.SmtpPort, cfg.SmtpUsername, cfg.SmtpPassword, cfg.MailFileDir)
+	if err != nil {
+		app.Logger.Fatal(err.Error())
+	}
+
+	return &mailService{
+		mailer: m,
+		from:   cfg.MailFrom,
+		appURL: cfg.AppURL,
+	}
+}
+
+// mailService struct that implements MailService interface
+type mailService struct {
+	mailer mailer.Mailer
+	from   string
+	appURL string
+}
+
+// MailService ensures interface implementation
+func (svc *mailService) MailService() string {
+	return "mailService"
+}
+
+// SendEmailVerification sends email address verification link to user
+func (svc *mailService) SendEmailVerification(user *models.User, plainToken string) error {
+	link := fmt.Sprintf("%s/account/email/verify?token=%s", svc.appURL, plainToken)
+
+	return svc.mailer.Send(mailer.Message{
+		From:    svc.from,
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hello %s,\r\n\r\nplease verify your email address by opening the link below:\r\n\r\n%s\r\n\r\nIf you did not create an account, you can ignore this message.\r\n",
+			user.FirstName, link),
+	})
+}
+
+// SendPasswordReset sends password reset link to user
+func (svc *mailService) SendPasswordReset(user *models.User, plainToken string) error {
+	link := fmt.Sprintf("%s/account/password/reset?token=%s", svc.appURL, plainToken)
+
+	return svc.mailer.Send(mailer.Message{
+		From:    svc.from,
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hello %s,\r\n\r\nyou can choose new password by opening the link below:\r\n\r\n%s\r\n\r\nIf you did not request password reset, you can ignore this message.\r\n",
+			user.FirstName, link),
+	})
+}
+
+// SendInvitation sends invitation link to invited email address
+func (svc *mailService) SendInvitation(inviter *models.User, invitation *models.Invitation, plainToken string) error {
+	link := fmt.Sprintf("%s/invitations/accept?token=%s", svc.appURL, plainToken)
+
+	return svc.mailer.Send(mailer.Message{
+		From:    svc.from,
+		To:      invitation.Email,
+		Subject: "You have been invited",
+		Body: fmt.Sprintf("Hello,\r\n\r\n%s %s has invited you to create an account. Accept the invitation by opening the link below:\r\n\r\n%s\r\n\r\nThe invitation expires on %s.\r\n",
+			inviter.FirstName, inviter.LastName, link, invitation.ExpiresAt.Format("2006-01-02 15:04 MST")),
+	})
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/config"
+	apperrors "github.com/go-flow/template-api/domain/errors"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/pkg/token"
+	"github.com/go-flow/template-api/repositories"
+	"github.com/jinzhu/gorm"
+)
+
+var (
+	// ErrInvalidOneTimeCode is returned when one time code does not match, is expired or already used
+	ErrInvalidOneTimeCode = apperrors.Validation(apperrors.CodeInvalidOneTimeCode, "invalid or expired code")
+
+	// ErrOneTimeCodeAttemptsExceeded is returned when one time code was guessed wrong too many times
+	ErrOneTimeCodeAttemptsExceeded = apperrors.RateLimited(apperrors.CodeOneTimeCodeAttemptsExceeded, "too many invalid attempts, please request new code")
+)
+
+// OneTimeCodeService defines set of available operations around OneTimeCode model
+type OneTimeCodeService interface {
+	// OneTimeCodeService ensures interface implementation
+	OneTimeCodeService() string
+
+	// GetLatest returns most recently issued one time code for given purpose
+	GetLatest(ctx context.Context, userID uint64, purpose string) (*models.OneTimeCode, error)
+
+	// Issue invalidates previously issued codes and creates new numeric code for given purpose
+	//
+	// plain text code is returned only once, database holds its keyed hash
+	Issue(ctx context.Context, userID uint64, purpose string, destination string) (string, *models.OneTimeCode, error)
+
+	// Verify checks plain text code against latest code issued for given purpose and marks it as used
+	Verify(ctx context.Context, userID uint64, purpose string, plainCode string) (*models.OneTimeCode, error)
+}
+
+// NewOneTimeCodeService creates new OneTimeCodeService implementation
+func NewOneTimeCodeService(app *flow.App) OneTimeCodeService {
+	cfg := app.AppConfig.(config.AppConfig)
+
+	return &oneTimeCodeService{
+		hashKey:     cfg.OtpHashKey,
+		length:      cfg.OtpLength,
+		ttl:         time.Minute * time.Duration(cfg.OtpTTL),
+		maxAttempts: cfg.OtpMaxAttempts,
+	}
+}
+
+// oneTimeCodeService struct that implements OneTimeCodeService interface
+type oneTimeCodeService struct {
+	// OneTimeCodeRepository implementation injected by dependency injection
+	OneTimeCodeRepository repositories.OneTimeCodeRepository
+
+	hashKey     string
+	length      int
+	ttl         time.Duration
+	maxAttempts int
+}
+
+// OneTimeCodeService ensures interface implementation
+func (svc *oneTimeCodeService) OneTimeCodeService() string {
+	return "oneTimeCodeService"
+}
+
+// GetLatest returns most recently issued one time code for given purpose
+func (svc *oneTimeCodeService) GetLatest(ctx context.Context, userID uint64, purpose string) (*models.OneTimeCode, error) {
+	return svc.OneTimeCodeRepository.GetLatestByUserID(ctx, userID, purpose)
+}
+
+// Issue invalidates previously issued codes and creates new numeric code for given purpose
+func (svc *oneTimeCodeService) Issue(ctx context.Context, userID uint64, purpose string, destination string) (string, *models.OneTimeCode, error) {
+	if err := svc.OneTimeCodeRepository.InvalidateAllByUserID(ctx, userID, purpose); err != nil {
+		return "", nil, err
+	}
+
+	plain, err := token.NumericCode(svc.length)
+	if err != nil {
+		return "", nil, err
+	}
+
+	model := &models.OneTimeCode{
+		UserID:      userID,
+		Purpose:     purpose,
+		Destination: destination,
+		CodeHash:    token.HashWithKey(svc.hashKey, plain),
+		ExpiresAt:   time.Now().Add(svc.ttl),
+	}
+
+	if err := svc.OneTimeCodeRepository.Create(ctx, model); err != nil {
+		return "", nil, err
+	}
+
+	return plain, model, nil
+}
+
+// Verify checks plain text code against latest code issued for given purpose and marks it as used
+//
+// every guess is counted before code is compared, once attempt limit is reached code can no longer be used
+func (svc *oneTimeCodeService) Verify(ctx context.Context, userID uint64, purpose string, plainCode string) (*models.OneTimeCode, error) {
+	model, err := svc.OneTimeCodeRepository.GetLatestByUserID(ctx, userID, purpose)
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, ErrInvalidOneTimeCode
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if model.UsedAt != nil || !time.Now().Before(model.ExpiresAt) {
+		return nil, ErrInvalidOneTimeCode
+	}
+
+	if model.Attempts >= svc.maxAttempts {
+		return nil, ErrOneTimeCodeAttemptsExceeded
+	}
+
+	// concurrent guesses may all pass the check above, only guesses counted within the limit are compared
+	counted, err := svc.OneTimeCodeRepository.IncrementAttempts(ctx, model, svc.maxAttempts)
+	if err != nil {
+		return nil, err
+	}
+	if !counted {
+		return nil, ErrOneTimeCodeAttemptsExceeded
+	}
+
+	if !token.EqualHash(model.CodeHash, token.HashWithKey(svc.hashKey, plainCode)) {
+		return nil, ErrInvalidOneTimeCode
+	}
+
+	used, err := svc.OneTimeCodeRepository.MarkUsed(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInvalidOneTimeCode
+	}
+
+	return model, nil
+}
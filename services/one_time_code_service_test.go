@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/mocks"
+	"github.com/go-flow/template-api/pkg/token"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OneTimeCodeServiceSuite struct {
+	suite.Suite
+
+	ctx                       context.Context
+	service                   OneTimeCodeService
+	oneTimeCodeRepositoryMock *mocks.OneTimeCodeRepositoryMock
+}
+
+// TestOneTimeCodeServiceSuite is one time code service test suite runner
+func TestOneTimeCodeServiceSuite(t *testing.T) {
+	suite.Run(t, new(OneTimeCodeServiceSuite))
+}
+
+// SetupSuite configures suite for unit testing
+func (s *OneTimeCodeServiceSuite) SetupSuite() {
+	s.ctx = db.WithRequestID(context.Background(), "request-id")
+}
+
+// BeforeTest is called before every test in OneTimeCodeServiceSuite
+func (s *OneTimeCodeServiceSuite) BeforeTest(_, _ string) {
+	s.oneTimeCodeRepositoryMock = mocks.NewOneTimeCodeRepositoryMock()
+
+	s.service = &oneTimeCodeService{
+		OneTimeCodeRepository: s.oneTimeCodeRepositoryMock,
+		hashKey:               "key",
+		length:                6,
+		ttl:                   time.Minute,
+		maxAttempts:           3,
+	}
+}
+
+// AfterTest ensures that all OneTimeCodeServiceSuite expectations were met
+func (s *OneTimeCodeServiceSuite) AfterTest(_, _ string) {
+	s.oneTimeCodeRepositoryMock.AssertExpectations(s.T())
+}
+
+func (s *OneTimeCodeServiceSuite) Test_Issue() {
+	purpose := models.OneTimeCodePurposePhoneVerification
+
+	s.oneTimeCodeRepositoryMock.On("InvalidateAllByUserID", s.ctx, uint64(1), purpose).Return(nil)
+	s.oneTimeCodeRepositoryMock.On("Create", s.ctx, mock.AnythingOfType("*models.OneTimeCode")).Return(nil)
+
+	plain, model, err := s.service.Issue(s.ctx, 1, purpose, "+38761123456")
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Regexp(s.T(), `^[0-9]{6}$`, plain, "expected 6 digit numeric code, got %s", plain)
+	assert.Equal(s.T(), token.HashWithKey("key", plain), model.CodeHash, "expected stored code to be keyed hash of plain code")
+	assert.Equal(s.T(), "+38761123456", model.Destination, "expected destination to be stored")
+	assert.True(s.T(), model.IsUsable(time.Now(), 3), "expected issued code to be usable")
+}
+
+func (s *OneTimeCodeServiceSuite) Test_Verify() {
+	purpose := models.OneTimeCodePurposePhoneVerification
+	model := &models.OneTimeCode{ID: 1, UserID: 1, Purpose: purpose, CodeHash: token.HashWithKey("key", "123456"), ExpiresAt: time.Now().Add(time.Minute)}
+
+	s.oneTimeCodeRepositoryMock.On("GetLatestByUserID", s.ctx, uint64(1), purpose).Return(model, nil)
+	s.oneTimeCodeRepositoryMock.On("IncrementAttempts", s.ctx, model, 3).Return(true, nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.OneTimeCode).Attempts++
+	})
+	s.oneTimeCodeRepositoryMock.On("MarkUsed", s.ctx, model).Return(true, nil)
+
+	_, err := s.service.Verify(s.ctx, 1, purpose, "000000")
+	assert.Equal(s.T(), ErrInvalidOneTimeCode, err, "expected wrong code to be rejected, got %v", err)
+	assert.Equal(s.T(), 1, model.Attempts, "expected failed attempt to be counted")
+
+	verified, err := s.service.Verify(s.ctx, 1, purpose, "123456")
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Equal(s.T(), model, verified, "expected verified code to be returned")
+}
+
+func (s *OneTimeCodeServiceSuite) Test_Verify_AttemptNotCounted() {
+	purpose := models.OneTimeCodePurposePhoneVerification
+	model := &models.OneTimeCode{ID: 1, UserID: 1, Purpose: purpose, CodeHash: token.HashWithKey("key", "123456"), ExpiresAt: time.Now().Add(time.Minute)}
+
+	// concurrent guesses used up attempts after code was read
+	s.oneTimeCodeRepositoryMock.On("GetLatestByUserID", s.ctx, uint64(1), purpose).Return(model, nil)
+	s.oneTimeCodeRepositoryMock.On("IncrementAttempts", s.ctx, model, 3).Return(false, nil)
+
+	_, err := s.service.Verify(s.ctx, 1, purpose, "123456")
+
+	assert.Equal(s.T(), ErrOneTimeCodeAttemptsExceeded, err, "expected guess over limit to be rejected, got %v", err)
+	s.oneTimeCodeRepositoryMock.AssertNotCalled(s.T(), "MarkUsed", s.ctx, model)
+}
+
+func (s *OneTimeCodeServiceSuite) Test_Verify_Rejected() {
+	purpose := models.OneTimeCodePurposePhoneVerification
+	hash := token.HashWithKey("key", "123456")
+	now := time.Now()
+
+	exhausted := &models.OneTimeCode{ID: 1, CodeHash: hash, Attempts: 3, ExpiresAt: now.Add(time.Minute)}
+	expired := &models.OneTimeCode{ID: 2, CodeHash: hash, ExpiresAt: now.Add(-time.Minute)}
+	used := &models.OneTimeCode{ID: 3, CodeHash: hash, ExpiresAt: now.Add(time.Minute), UsedAt: &now}
+
+	s.oneTimeCodeRepositoryMock.On("GetLatestByUserID", s.ctx, uint64(1), purpose).Return(exhausted, nil)
+	s.oneTimeCodeRepositoryMock.On("GetLatestByUserID", s.ctx, uint64(2), purpose).Return(expired, nil)
+	s.oneTimeCodeRepositoryMock.On("GetLatestByUserID", s.ctx, uint64(3), purpose).Return(used, nil)
+	s.oneTimeCodeRepositoryMock.On("GetLatestByUserID", s.ctx, uint64(4), purpose).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.service.Verify(s.ctx, 1, purpose, "123456")
+	assert.Equal(s.T(), ErrOneTimeCodeAttemptsExceeded, err, "expected exhausted code to be rejected, got %v", err)
+
+	for _, userID := range []uint64{2, 3, 4} {
+		_, err = s.service.Verify(s.ctx, userID, purpose, "123456")
+		assert.Equal(s.T(), ErrInvalidOneTimeCode, err, "expected code of user %d to be rejected, got %v", userID, err)
+	}
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/repositories"
+)
+
+// PermissionWildcard grants every permission
+const PermissionWildcard = "*"
+
+// PermissionService defines set of available operations around roles and permissions
+type PermissionService interface {
+	// PermissionService ensures interface implementation
+	PermissionService() string
+
+	// HasPermission checks if user is granted given permission
+	HasPermission(ctx context.Context, userID uint64, permission string) (bool, error)
+
+	// GetRoles returns all roles assigned to given user
+	GetRoles(ctx context.Context, userID uint64) ([]*models.Role, error)
+
+	// AssignRole assigns role with given name to user
+	AssignRole(ctx context.Context, userID uint64, roleName string) error
+
+	// RevokeRole removes role with given name from user
+	RevokeRole(ctx context.Context, userID uint64, roleName string) error
+}
+
+// NewPermissionService creates new PermissionService implementation
+func NewPermissionService(app *flow.App) PermissionService {
+	return &permissionService{}
+}
+
+// permissionService struct that implements PermissionService interface
+type permissionService struct {
+	// RoleRepository implementation injected by dependency injection
+	RoleRepository repositories.RoleRepository
+}
+
+// PermissionService ensures interface implementation
+func (svc *permissionService) PermissionService() string {
+	return "permissionService"
+}
+
+// HasPermission checks if user is granted given permission
+//
+// permission `users:delete` is granted by `users:delete`, `users:*` or `*`
+func (svc *permissionService) HasPermission(ctx context.Context, userID uint64, permission string) (bool, error) {
+	granted, err := svc.RoleRepository.GetPermissionNamesByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	resource := permission
+	if i := strings.Index(permission, ":"); i >= 0 {
+		resource = permission[:i]
+	}
+
+	for _, name := range granted {
+		if name == permission || name == PermissionWildcard || name == resource+":"+PermissionWildcard {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetRoles returns all roles assigned to given user
+func (svc *permissionService) GetRoles(ctx context.Context, userID uint64) ([]*models.Role, error) {
+	return svc.RoleRepository.GetByUserID(ctx, userID)
+}
+
+// AssignRole assigns role with given name to user
+func (svc *permissionService) AssignRole(ctx context.Context, userID uint64, roleName string) error {
+	role, err := svc.RoleRepository.GetByName(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	return svc.RoleRepository.AssignToUser(ctx, userID, role.ID)
+}
+
+// RevokeRole removes role with given name from user
+func (svc *permissionService) RevokeRole(ctx context.Context, userID uint64, roleName string) error {
+	role, err := svc.RoleRepository.GetByName(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	return svc.RoleRepository.RemoveFromUser(ctx, userID, role.ID)
+}
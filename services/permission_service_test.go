@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PermissionServiceSuite struct {
+	suite.Suite
+
+	ctx                context.Context
+	service            PermissionService
+	roleRepositoryMock *mocks.RoleRepositoryMock
+}
+
+// TestPermissionServiceSuite is permission service test suite runner
+func TestPermissionServiceSuite(t *testing.T) {
+	suite.Run(t, new(PermissionServiceSuite))
+}
+
+// SetupSuite configures suite for unit testing
+func (s *PermissionServiceSuite) SetupSuite() {
+	s.ctx = db.WithRequestID(context.Background(), "request-id")
+}
+
+// BeforeTest is called before every test in PermissionServiceSuite
+func (s *PermissionServiceSuite) BeforeTest(_, _ string) {
+	s.roleRepositoryMock = mocks.NewRoleRepositoryMock()
+
+	s.service = &permissionService{
+		RoleRepository: s.roleRepositoryMock,
+	}
+}
+
+// AfterTest ensures that all PermissionServiceSuite expectations were met
+func (s *PermissionServiceSuite) AfterTest(_, _ string) {
+	s.roleRepositoryMock.AssertExpectations(s.T())
+}
+
+func (s *PermissionServiceSuite) Test_HasPermission() {
+	s.roleRepositoryMock.On("GetPermissionNamesByUserID", s.ctx, uint64(1)).Return([]string{"users:read"}, nil)
+	s.roleRepositoryMock.On("GetPermissionNamesByUserID", s.ctx, uint64(2)).Return([]string{"users:*"}, nil)
+	s.roleRepositoryMock.On("GetPermissionNamesByUserID", s.ctx, uint64(3)).Return([]string{"*"}, nil)
+	s.roleRepositoryMock.On("GetPermissionNamesByUserID", s.ctx, uint64(4)).Return([]string{}, nil)
+
+	cases := []struct {
+		userID     uint64
+		permission string
+		expected   bool
+	}{
+		{1, "users:read", true},
+		{1, "users:delete", false},
+		{2, "users:delete", true},
+		{2, "roles:assign", false},
+		{3, "roles:assign", true},
+		{4, "users:read", false},
+	}
+
+	for _, c := range cases {
+		ok, err := s.service.HasPermission(s.ctx, c.userID, c.permission)
+
+		assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+		assert.Equal(s.T(), c.expected, ok, "expected user %d permission `%s` to be %v", c.userID, c.permission, c.expected)
+	}
+}
+
+func (s *PermissionServiceSuite) Test_AssignRole() {
+	s.roleRepositoryMock.On("GetByName", s.ctx, "admin").Return(&models.Role{ID: 1, Name: "admin"}, nil)
+	s.roleRepositoryMock.On("AssignToUser", s.ctx, uint64(5), uint64(1)).Return(nil)
+
+	err := s.service.AssignRole(s.ctx, 5, "admin")
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/pkg/token"
+	"github.com/go-flow/template-api/repositories"
+)
+
+// refreshTokenSize is number of random bytes used for refresh tokens
+const refreshTokenSize = 32
+
+// RefreshTokenService defines set of available operations around RefreshToken model
+type RefreshTokenService interface {
+	// RefreshTokenService ensures interface implementation
+	RefreshTokenService() string
+
+	// GetByToken returns refresh token model for given plain text token
+	GetByToken(ctx context.Context, plainToken string) (*models.RefreshToken, error)
+
+	// Issue creates new refresh token for given user valid for ttl duration
+	//
+	// plain text token is returned only once, database holds its hash
+	Issue(ctx context.Context, userID uint64, ttl time.Duration) (string, *models.RefreshToken, error)
+
+	// Revoke marks refresh token as revoked
+	Revoke(ctx context.Context, refreshToken *models.RefreshToken) error
+
+	// Rotate revokes refresh token as replaced by token with given id, it reports false when token was already revoked
+	Rotate(ctx context.Context, refreshToken *models.RefreshToken, replacedByID uint64) (bool, error)
+
+	// RevokeAll revokes all active refresh tokens issued to given user
+	RevokeAll(ctx context.Context, userID uint64) error
+}
+
+// NewRefreshTokenService creates new RefreshTokenService implementation
+func NewRefreshTokenService(app *flow.App) RefreshTokenService {
+	return &refreshTokenService{}
+}
+
+// refreshTokenService struct that implements RefreshTokenService interface
+type refreshTokenService struct {
+	// RefreshTokenRepository implementation injected by dependency injection
+	RefreshTokenRepository repositories.RefreshTokenRepository
+}
+
+// RefreshTokenService ensures interface implementation
+func (svc *refreshTokenService) RefreshTokenService() string {
+	return "refreshTokenService"
+}
+
+// GetByToken returns refresh token model for given plain text token
+func (svc *refreshTokenService) GetByToken(ctx context.Context, plainToken string) (*models.RefreshToken, error) {
+	return svc.RefreshTokenRepository.GetByTokenHash(ctx, token.Hash(plainToken))
+}
+
+// Issue creates new refresh token for given user valid for ttl duration
+func (svc *refreshTokenService) Issue(ctx context.Context, userID uint64, ttl time.Duration) (string, *models.RefreshToken, error) {
+	plain, err := token.Random(refreshTokenSize)
+	if err != nil {
+		return "", nil, err
+	}
+
+	model := &models.RefreshToken{
+		UserID:    userID,
+		TokenHash: token.Hash(plain),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	if err := svc.RefreshTokenRepository.Create(ctx, model); err != nil {
+		return "", nil, err
+	}
+
+	return plain, model, nil
+}
+
+// Revoke marks refresh token as revoked
+func (svc *refreshTokenService) Revoke(ctx context.Context, refreshToken *models.RefreshToken) error {
+	if refreshToken.RevokedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	refreshToken.RevokedAt = &now
+
+	return svc.RefreshTokenRepository.Update(ctx, refreshToken)
+}
+
+// Rotate revokes refresh token as replaced by token with given id, it reports false when token was already revoked
+func (svc *refreshTokenService) Rotate(ctx context.Context, refreshToken *models.RefreshToken, replacedByID uint64) (bool, error) {
+	return svc.RefreshTokenRepository.Rotate(ctx, refreshToken, replacedByID)
+}
+
+// RevokeAll revokes all active refresh tokens issued to given user
+func (svc *refreshTokenService) RevokeAll(ctx context.Context, userID uint64) error {
+	return svc.RefreshTokenRepository.RevokeAllByUserID(ctx, userID)
+}
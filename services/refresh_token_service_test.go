@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/mocks"
+	"github.com/go-flow/template-api/pkg/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RefreshTokenServiceSuite struct {
+	suite.Suite
+
+	ctx                        context.Context
+	service                    RefreshTokenService
+	refreshTokenRepositoryMock *mocks.RefreshTokenRepositoryMock
+}
+
+// TestRefreshTokenServiceSuite is refresh token service test suite runner
+func TestRefreshTokenServiceSuite(t *testing.T) {
+	suite.Run(t, new(RefreshTokenServiceSuite))
+}
+
+// SetupSuite configures suite for unit testing
+func (s *RefreshTokenServiceSuite) SetupSuite() {
+	s.ctx = db.WithRequestID(context.Background(), "request-id")
+}
+
+// BeforeTest is called before every test in RefreshTokenServiceSuite
+func (s *RefreshTokenServiceSuite) BeforeTest(_, _ string) {
+	s.refreshTokenRepositoryMock = mocks.NewRefreshTokenRepositoryMock()
+
+	s.service = &refreshTokenService{
+		RefreshTokenRepository: s.refreshTokenRepositoryMock,
+	}
+}
+
+// AfterTest ensures that all RefreshTokenServiceSuite expectations were met
+func (s *RefreshTokenServiceSuite) AfterTest(_, _ string) {
+	s.refreshTokenRepositoryMock.AssertExpectations(s.T())
+}
+
+func (s *RefreshTokenServiceSuite) Test_Issue() {
+	s.refreshTokenRepositoryMock.On("Create", s.ctx, mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+
+	plain, model, err := s.service.Issue(s.ctx, 1, time.Hour)
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.NotEmpty(s.T(), plain, "expected plain token to be returned")
+	assert.Equal(s.T(), token.Hash(plain), model.TokenHash, "expected stored token to be hash of plain token")
+	assert.NotEqual(s.T(), plain, model.TokenHash, "expected plain token not to be stored")
+	assert.Equal(s.T(), uint64(1), model.UserID, "expected user id to be 1, got %v", model.UserID)
+}
+
+func (s *RefreshTokenServiceSuite) Test_GetByToken() {
+	model := &models.RefreshToken{ID: 1, UserID: 1, TokenHash: token.Hash("plain")}
+	s.refreshTokenRepositoryMock.On("GetByTokenHash", s.ctx, token.Hash("plain")).Return(model, nil)
+
+	rt, err := s.service.GetByToken(s.ctx, "plain")
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Equal(s.T(), model, rt, "expected returned model to be looked up by token hash")
+}
+
+func (s *RefreshTokenServiceSuite) Test_Revoke() {
+	model := &models.RefreshToken{ID: 1, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	s.refreshTokenRepositoryMock.On("Update", s.ctx, model).Return(nil).Once()
+
+	err := s.service.Revoke(s.ctx, model)
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.NotNil(s.T(), model.RevokedAt, "expected token to be revoked")
+	assert.False(s.T(), model.IsActive(time.Now()), "expected revoked token not to be active")
+
+	// revoking already revoked token is a no-op
+	err = s.service.Revoke(s.ctx, model)
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+}
+
+func (s *RefreshTokenServiceSuite) Test_Rotate() {
+	model := &models.RefreshToken{ID: 1, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	s.refreshTokenRepositoryMock.On("Rotate", s.ctx, model, uint64(2)).Return(true, nil).Once()
+	s.refreshTokenRepositoryMock.On("Rotate", s.ctx, model, uint64(3)).Return(false, nil).Once()
+
+	rotated, err := s.service.Rotate(s.ctx, model, 2)
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.True(s.T(), rotated, "expected token to be rotated")
+
+	// token rotated by concurrent request is reported
+	rotated, err = s.service.Rotate(s.ctx, model, 3)
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.False(s.T(), rotated, "expected already rotated token not to be rotated")
+}
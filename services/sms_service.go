@@ -0,0 +1,52 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/config"
+	"github.com/go-flow/template-api/pkg/sms"
+)
+
+// SmsService defines set of text messages application sends to users
+type SmsService interface {
+	// SmsService ensures interface implementation
+	SmsService() string
+
+	// SendPhoneVerification sends phone verification code to given phone number
+	SendPhoneVerification(phoneNumber string, plainCode string) error
+}
+
+// NewSmsService creates new SmsService implementation
+//
+// sender implementation is selected by SmsDriver configuration
+func NewSmsService(app *flow.App) SmsService {
+	cfg := app.AppConfig.(config.AppConfig)
+
+	sender, err := sms.New(cfg.SmsDriver, app.Logger)
+	if err != nil {
+		app.Logger.Fatal(err.Error())
+	}
+
+	return &smsService{
+		sender: sender,
+	}
+}
+
+// smsService struct that implements SmsService interface
+type smsService struct {
+	sender sms.SMSSender
+}
+
+// SmsService ensures interface implementation
+func (svc *smsService) SmsService() string {
+	return "smsService"
+}
+
+// SendPhoneVerification sends phone verification code to given phone number
+func (svc *smsService) SendPhoneVerification(phoneNumber string, plainCode string) error {
+	return svc.sender.Send(sms.Message{
+		To:   phoneNumber,
+		Body: fmt.Sprintf("Your verification code is %s", plainCode),
+	})
+}
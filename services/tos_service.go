@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	apperrors "github.com/go-flow/template-api/domain/errors"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/repositories"
+	"github.com/jinzhu/gorm"
+)
+
+// ErrNoTosVersion is returned when no terms of service version has been published yet
+var ErrNoTosVersion = apperrors.NotFound(apperrors.CodeNoTosVersion, "terms of service have not been published")
+
+// TosService defines set of available operations around terms of service versions and acceptances
+type TosService interface {
+	// TosService ensures interface implementation
+	TosService() string
+
+	// GetCurrent returns most recently published terms of service version
+	GetCurrent(ctx context.Context) (*models.TosVersion, error)
+
+	// Publish creates new terms of service version
+	Publish(ctx context.Context, version *models.TosVersion) error
+
+	// HasAccepted reports whether user accepted given terms of service version
+	HasAccepted(ctx context.Context, userID uint64, tosVersionID uint64) (bool, error)
+
+	// Accept records user accepting given terms of service version
+	//
+	// accepting already accepted version returns existing acceptance
+	Accept(ctx context.Context, userID uint64, tosVersionID uint64, ipAddress string, userAgent string) (*models.UserTosAcceptance, error)
+
+	// GetAcceptances returns terms of service acceptance history of given user
+	GetAcceptances(ctx context.Context, userID uint64) ([]*models.UserTosAcceptance, error)
+}
+
+// NewTosService creates new TosService implementation
+func NewTosService(app *flow.App) TosService {
+	return &tosService{}
+}
+
+// tosService struct that implements TosService interface
+type tosService struct {
+	// TosRepository implementation injected by dependency injection
+	TosRepository repositories.TosRepository
+}
+
+// TosService ensures interface implementation
+func (svc *tosService) TosService() string {
+	return "tosService"
+}
+
+// GetCurrent returns most recently published terms of service version
+func (svc *tosService) GetCurrent(ctx context.Context) (*models.TosVersion, error) {
+	model, err := svc.TosRepository.GetCurrent(ctx)
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, ErrNoTosVersion
+	}
+	return model, err
+}
+
+// Publish creates new terms of service version
+//
+// version without publish date is published immediately
+func (svc *tosService) Publish(ctx context.Context, version *models.TosVersion) error {
+	if version.PublishedAt.IsZero() {
+		version.PublishedAt = time.Now()
+	}
+
+	return svc.TosRepository.Create(ctx, version)
+}
+
+// HasAccepted reports whether user accepted given terms of service version
+func (svc *tosService) HasAccepted(ctx context.Context, userID uint64, tosVersionID uint64) (bool, error) {
+	_, err := svc.TosRepository.GetAcceptance(ctx, userID, tosVersionID)
+	if gorm.IsRecordNotFoundError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Accept records user accepting given terms of service version
+func (svc *tosService) Accept(ctx context.Context, userID uint64, tosVersionID uint64, ipAddress string, userAgent string) (*models.UserTosAcceptance, error) {
+	existing, err := svc.TosRepository.GetAcceptance(ctx, userID, tosVersionID)
+	if err == nil {
+		return existing, nil
+	}
+	if !gorm.IsRecordNotFoundError(err) {
+		return nil, err
+	}
+
+	if len(userAgent) > 512 {
+		userAgent = userAgent[:512]
+	}
+
+	acceptance := &models.UserTosAcceptance{
+		UserID:       userID,
+		TosVersionID: tosVersionID,
+		IPAddress:    ipAddress,
+		UserAgent:    userAgent,
+		AcceptedAt:   time.Now(),
+	}
+
+	if err := svc.TosRepository.CreateAcceptance(ctx, acceptance); err != nil {
+		return nil, err
+	}
+
+	return acceptance, nil
+}
+
+// GetAcceptances returns terms of service acceptance history of given user
+func (svc *tosService) GetAcceptances(ctx context.Context, userID uint64) ([]*models.UserTosAcceptance, error) {
+	return svc.TosRepository.GetAcceptancesByUserID(ctx, userID)
+}
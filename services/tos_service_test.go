@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/mocks"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TosServiceSuite struct {
+	suite.Suite
+
+	ctx               context.Context
+	service           TosService
+	tosRepositoryMock *mocks.TosRepositoryMock
+}
+
+// TestTosServiceSuite is terms of service service test suite runner
+func TestTosServiceSuite(t *testing.T) {
+	suite.Run(t, new(TosServiceSuite))
+}
+
+// SetupSuite configures suite for unit testing
+func (s *TosServiceSuite) SetupSuite() {
+	s.ctx = db.WithRequestID(context.Background(), "request-id")
+}
+
+// BeforeTest is called before every test in TosServiceSuite
+func (s *TosServiceSuite) BeforeTest(_, _ string) {
+	s.tosRepositoryMock = mocks.NewTosRepositoryMock()
+
+	s.service = &tosService{
+		TosRepository: s.tosRepositoryMock,
+	}
+}
+
+// AfterTest ensures that all TosServiceSuite expectations were met
+func (s *TosServiceSuite) AfterTest(_, _ string) {
+	s.tosRepositoryMock.AssertExpectations(s.T())
+}
+
+func (s *TosServiceSuite) Test_GetCurrent_None() {
+	s.tosRepositoryMock.On("GetCurrent", s.ctx).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.service.GetCurrent(s.ctx)
+
+	assert.Equal(s.T(), ErrNoTosVersion, err, "expected missing version to be reported, got %v", err)
+}
+
+func (s *TosServiceSuite) Test_Publish() {
+	version := &models.TosVersion{Version: "2024-01"}
+
+	s.tosRepositoryMock.On("Create", s.ctx, version).Return(nil)
+
+	err := s.service.Publish(s.ctx, version)
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.False(s.T(), version.PublishedAt.IsZero(), "expected version to be published immediately")
+}
+
+func (s *TosServiceSuite) Test_Accept() {
+	s.tosRepositoryMock.On("GetAcceptance", s.ctx, uint64(1), uint64(2)).Return(nil, gorm.ErrRecordNotFound)
+	s.tosRepositoryMock.On("CreateAcceptance", s.ctx, mock.AnythingOfType("*models.UserTosAcceptance")).Return(nil)
+
+	acceptance, err := s.service.Accept(s.ctx, 1, 2, "127.0.0.1", "curl/7.68.0")
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Equal(s.T(), "127.0.0.1", acceptance.IPAddress, "expected client IP to be recorded")
+	assert.Equal(s.T(), "curl/7.68.0", acceptance.UserAgent, "expected user agent to be recorded")
+	assert.False(s.T(), acceptance.AcceptedAt.IsZero(), "expected acceptance time to be recorded")
+}
+
+func (s *TosServiceSuite) Test_Accept_AlreadyAccepted() {
+	existing := &models.UserTosAcceptance{ID: 5, UserID: 1, TosVersionID: 2}
+
+	s.tosRepositoryMock.On("GetAcceptance", s.ctx, uint64(1), uint64(2)).Return(existing, nil)
+
+	acceptance, err := s.service.Accept(s.ctx, 1, 2, "127.0.0.1", "curl/7.68.0")
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Equal(s.T(), existing, acceptance, "expected existing acceptance to be returned")
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/go-flow/flow"
+	"github.com/go-flow/template-api/config"
+	apperrors "github.com/go-flow/template-api/domain/errors"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/pkg/encryption"
+	"github.com/go-flow/template-api/pkg/token"
+	"github.com/go-flow/template-api/pkg/totp"
+	"github.com/go-flow/template-api/repositories"
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	// twoFactorSkew is number of time steps accepted before and after current one
+	twoFactorSkew = 1
+
+	// recoveryCodeSize is number of random bytes used for recovery codes
+	recoveryCodeSize = 10
+)
+
+var (
+	// ErrTwoFactorNotEnrolled is returned when user has not started two-factor enrollment
+	ErrTwoFactorNotEnrolled = apperrors.Conflict(apperrors.CodeTwoFactorNotEnrolled, "two-factor authentication is not enrolled")
+
+	// ErrTwoFactorAlreadyEnabled is returned when enrolling user who already has two-factor authentication enabled
+	ErrTwoFactorAlreadyEnabled = apperrors.Conflict(apperrors.CodeTwoFactorAlreadyEnabled, "two-factor authentication is already enabled")
+
+	// ErrInvalidTwoFactorCode is returned when authentication or recovery code is not valid
+	ErrInvalidTwoFactorCode = apperrors.Validation(apperrors.CodeInvalidTwoFactorCode, "invalid two-factor code")
+)
+
+// TwoFactorService defines set of available operations around TOTP two-factor authentication
+type TwoFactorService interface {
+	// TwoFactorService ensures interface implementation
+	TwoFactorService() string
+
+	// IsEnabled reports whether user has confirmed two-factor authentication
+	IsEnabled(ctx context.Context, userID uint64) (bool, error)
+
+	// Enroll creates new pending TOTP secret for user and returns enrollment details
+	Enroll(ctx context.Context, user *models.User) (*models.TwoFactorEnrollment, error)
+
+	// Confirm enables pending two-factor credential using code from authenticator app
+	// and returns newly generated recovery codes
+	Confirm(ctx context.Context, userID uint64, code string) ([]string, error)
+
+	// Verify checks authentication code or unused recovery code of user with enabled two-factor authentication
+	Verify(ctx context.Context, userID uint64, code string) error
+
+	// Disable removes two-factor credential and recovery codes of user
+	Disable(ctx context.Context, userID uint64) error
+}
+
+// NewTwoFactorService creates new TwoFactorService implementation
+func NewTwoFactorService(app *flow.App) TwoFactorService {
+	cfg := app.AppConfig.(config.AppConfig)
+
+	encrypter, err := encryption.NewEncrypter(cfg.TwoFactorEncryptionKey)
+	if err != nil {
+		app.Logger.Fatal(err.Error())
+	}
+
+	return &twoFactorService{
+		encrypter:         encrypter,
+		issuer:            cfg.TwoFactorIssuer,
+		recoveryCodeCount: cfg.TwoFactorRecoveryCodes,
+	}
+}
+
+// twoFactorService struct that implements TwoFactorService interface
+type twoFactorService struct {
+	// TwoFactorRepository implementation injected by dependency injection
+	TwoFactorRepository repositories.TwoFactorRepository
+
+	encrypter         *encryption.Encrypter
+	issuer            string
+	recoveryCodeCount int
+}
+
+// TwoFactorService ensures interface implementation
+func (svc *twoFactorService) TwoFactorService() string {
+	return "twoFactorService"
+}
+
+// IsEnabled reports whether user has confirmed two-factor authentication
+func (svc *twoFactorService) IsEnabled(ctx context.Context, userID uint64) (bool, error) {
+	credential, err := svc.TwoFactorRepository.GetByUserID(ctx, userID)
+	if gorm.IsRecordNotFoundError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return credential.IsEnabled(), nil
+}
+
+// Enroll creates new pending TOTP secret for user and returns enrollment details
+//
+// enrolling again before confirmation replaces pending secret
+func (svc *twoFactorService) Enroll(ctx context.Context, user *models.User) (*models.TwoFactorEnrollment, error) {
+	credential, err := svc.TwoFactorRepository.GetByUserID(ctx, user.ID)
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return nil, err
+	}
+	if err == nil && credential.IsEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if err != nil {
+		credential = &models.TwoFactorCredential{UserID: user.ID}
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := svc.encrypter.Encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	credential.SecretEncrypted = encrypted
+	credential.LastUsedStep = 0
+
+	if err := svc.TwoFactorRepository.Save(ctx, credential); err != nil {
+		return nil, err
+	}
+
+	return &models.TwoFactorEnrollment{
+		Secret:     secret,
+		OtpauthURI: totp.URI(svc.issuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm enables pending two-factor credential using code from authenticator app
+// and returns newly generated recovery codes
+func (svc *twoFactorService) Confirm(ctx context.Context, userID uint64, code string) ([]string, error) {
+	credential, err := svc.TwoFactorRepository.GetByUserID(ctx, userID)
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if credential.IsEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	if err := svc.verifyCode(ctx, credential, code); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	credential.EnabledAt = &now
+
+	if err := svc.TwoFactorRepository.Save(ctx, credential); err != nil {
+		return nil, err
+	}
+
+	plain := make([]string, svc.recoveryCodeCount)
+	codes := make([]*models.TwoFactorRecoveryCode, svc.recoveryCodeCount)
+
+	for i := range plain {
+		recovery, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		plain[i] = recovery
+		codes[i] = &models.TwoFactorRecoveryCode{
+			CodeHash: token.Hash(normalizeRecoveryCode(recovery)),
+		}
+	}
+
+	if err := svc.TwoFactorRepository.ReplaceRecoveryCodes(ctx, userID, codes); err != nil {
+		return nil, err
+	}
+
+	return plain, nil
+}
+
+// Verify checks authentication code or unused recovery code of user with enabled two-factor authentication
+//
+// codes from authenticator app can be used only once, recovery codes are consumed on use
+func (svc *twoFactorService) Verify(ctx context.Context, userID uint64, code string) error {
+	credential, err := svc.TwoFactorRepository.GetByUserID(ctx, userID)
+	if gorm.IsRecordNotFoundError(err) {
+		return ErrTwoFactorNotEnrolled
+	}
+	if err != nil {
+		return err
+	}
+
+	if !credential.IsEnabled() {
+		return ErrTwoFactorNotEnrolled
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return svc.verifyCode(ctx, credential, code)
+	}
+
+	used, err := svc.TwoFactorRepository.MarkRecoveryCodeUsed(ctx, userID, token.Hash(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+// Disable removes two-factor credential and recovery codes of user
+func (svc *twoFactorService) Disable(ctx context.Context, userID uint64) error {
+	return svc.TwoFactorRepository.DeleteByUserID(ctx, userID)
+}
+
+// verifyCode checks authentication code against credential secret and rejects replayed codes
+func (svc *twoFactorService) verifyCode(ctx context.Context, credential *models.TwoFactorCredential, code string) error {
+	secret, err := svc.encrypter.Decrypt(credential.SecretEncrypted)
+	if err != nil {
+		return err
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now(), twoFactorSkew)
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	fresh, err := svc.TwoFactorRepository.UpdateLastUsedStep(ctx, credential, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+// newRecoveryCode returns random recovery code formatted as two groups of lowercase base32 characters
+func newRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(b))
+	return code[:len(code)/2] + "-" + code[len(code)/2:], nil
+}
+
+// normalizeRecoveryCode strips separators and casing users may add when typing recovery code
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
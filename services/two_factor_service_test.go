@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/mocks"
+	"github.com/go-flow/template-api/pkg/encryption"
+	"github.com/go-flow/template-api/pkg/token"
+	"github.com/go-flow/template-api/pkg/totp"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TwoFactorServiceSuite struct {
+	suite.Suite
+
+	ctx                     context.Context
+	service                 TwoFactorService
+	encrypter               *encryption.Encrypter
+	twoFactorRepositoryMock *mocks.TwoFactorRepositoryMock
+}
+
+// TestTwoFactorServiceSuite is two-factor service test suite runner
+func TestTwoFactorServiceSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorServiceSuite))
+}
+
+// SetupSuite configures suite for unit testing
+func (s *TwoFactorServiceSuite) SetupSuite() {
+	s.ctx = db.WithRequestID(context.Background(), "request-id")
+}
+
+// BeforeTest is called before every test in TwoFactorServiceSuite
+func (s *TwoFactorServiceSuite) BeforeTest(_, _ string) {
+	s.twoFactorRepositoryMock = mocks.NewTwoFactorRepositoryMock()
+	s.encrypter, _ = encryption.NewEncrypter("key")
+
+	s.service = &twoFactorService{
+		TwoFactorRepository: s.twoFactorRepositoryMock,
+		encrypter:           s.encrypter,
+		issuer:              "template-api",
+		recoveryCodeCount:   3,
+	}
+}
+
+// AfterTest ensures that all TwoFactorServiceSuite expectations were met
+func (s *TwoFactorServiceSuite) AfterTest(_, _ string) {
+	s.twoFactorRepositoryMock.AssertExpectations(s.T())
+}
+
+// credential returns two-factor credential for given secret
+func (s *TwoFactorServiceSuite) credential(secret string, enabled bool) *models.TwoFactorCredential {
+	encrypted, _ := s.encrypter.Encrypt(secret)
+
+	credential := &models.TwoFactorCredential{UserID: 1, SecretEncrypted: encrypted}
+	if enabled {
+		now := time.Now()
+		credential.EnabledAt = &now
+	}
+	return credential
+}
+
+func (s *TwoFactorServiceSuite) Test_Enroll() {
+	s.twoFactorRepositoryMock.On("GetByUserID", s.ctx, uint64(1)).Return(nil, gorm.ErrRecordNotFound)
+	s.twoFactorRepositoryMock.On("Save", s.ctx, mock.AnythingOfType("*models.TwoFactorCredential")).Return(nil)
+
+	enrollment, err := s.service.Enroll(s.ctx, &models.User{ID: 1, Email: "john@example.com"})
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Contains(s.T(), enrollment.OtpauthURI, "secret="+enrollment.Secret, "expected otpauth URI to contain secret")
+
+	saved := s.twoFactorRepositoryMock.Calls[1].Arguments.Get(1).(*models.TwoFactorCredential)
+	assert.NotContains(s.T(), saved.SecretEncrypted, enrollment.Secret, "expected secret to be stored encrypted")
+	assert.False(s.T(), saved.IsEnabled(), "expected credential to wait for confirmation")
+}
+
+func (s *TwoFactorServiceSuite) Test_Enroll_AlreadyEnabled() {
+	s.twoFactorRepositoryMock.On("GetByUserID", s.ctx, uint64(1)).Return(s.credential("JBSWY3DPEHPK3PXP", true), nil)
+
+	_, err := s.service.Enroll(s.ctx, &models.User{ID: 1})
+
+	assert.Equal(s.T(), ErrTwoFactorAlreadyEnabled, err, "expected enrollment to be rejected, got %v", err)
+}
+
+func (s *TwoFactorServiceSuite) Test_Confirm() {
+	secret := "JBSWY3DPEHPK3PXP"
+	credential := s.credential(secret, false)
+	step := totp.Step(time.Now())
+	code, _ := totp.CodeAt(secret, step)
+
+	s.twoFactorRepositoryMock.On("GetByUserID", s.ctx, uint64(1)).Return(credential, nil)
+	s.twoFactorRepositoryMock.On("UpdateLastUsedStep", s.ctx, credential, step).Return(true, nil)
+	s.twoFactorRepositoryMock.On("Save", s.ctx, credential).Return(nil)
+	s.twoFactorRepositoryMock.On("ReplaceRecoveryCodes", s.ctx, uint64(1), mock.AnythingOfType("[]*models.TwoFactorRecoveryCode")).Return(nil)
+
+	codes, err := s.service.Confirm(s.ctx, 1, code)
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Len(s.T(), codes, 3, "expected configured number of recovery codes")
+	assert.True(s.T(), credential.IsEnabled(), "expected credential to be enabled")
+
+	stored := s.twoFactorRepositoryMock.Calls[3].Arguments.Get(2).([]*models.TwoFactorRecoveryCode)
+	assert.Equal(s.T(), token.Hash(normalizeRecoveryCode(codes[0])), stored[0].CodeHash, "expected recovery codes to be stored hashed")
+}
+
+func (s *TwoFactorServiceSuite) Test_Verify() {
+	secret := "JBSWY3DPEHPK3PXP"
+	credential := s.credential(secret, true)
+	step := totp.Step(time.Now())
+	code, _ := totp.CodeAt(secret, step)
+
+	s.twoFactorRepositoryMock.On("GetByUserID", s.ctx, uint64(1)).Return(credential, nil)
+	s.twoFactorRepositoryMock.On("UpdateLastUsedStep", s.ctx, credential, step).Return(true, nil).Once()
+	s.twoFactorRepositoryMock.On("UpdateLastUsedStep", s.ctx, credential, step).Return(false, nil).Once()
+	s.twoFactorRepositoryMock.On("MarkRecoveryCodeUsed", s.ctx, uint64(1), token.Hash("abcdefghijklmnop")).Return(true, nil).Once()
+	s.twoFactorRepositoryMock.On("MarkRecoveryCodeUsed", s.ctx, uint64(1), token.Hash("abcdefghijklmnop")).Return(false, nil).Once()
+
+	assert.Nil(s.T(), s.service.Verify(s.ctx, 1, code), "expected authentication code to be accepted")
+	assert.Equal(s.T(), ErrInvalidTwoFactorCode, s.service.Verify(s.ctx, 1, code), "expected replayed authentication code to be rejected")
+	assert.Nil(s.T(), s.service.Verify(s.ctx, 1, "ABCDEFGH-ijklmnop"), "expected recovery code to be accepted")
+	assert.Equal(s.T(), ErrInvalidTwoFactorCode, s.service.Verify(s.ctx, 1, "abcdefgh-ijklmnop"), "expected used recovery code to be rejected")
+}
+
+func (s *TwoFactorServiceSuite) Test_Verify_NotEnabled() {
+	s.twoFactorRepositoryMock.On("GetByUserID", s.ctx, uint64(1)).Return(s.credential("JBSWY3DPEHPK3PXP", false), nil)
+	s.twoFactorRepositoryMock.On("GetByUserID", s.ctx, uint64(2)).Return(nil, gorm.ErrRecordNotFound)
+
+	assert.Equal(s.T(), ErrTwoFactorNotEnrolled, s.service.Verify(s.ctx, 1, "123456"), "expected pending credential to be rejected")
+	assert.Equal(s.T(), ErrTwoFactorNotEnrolled, s.service.Verify(s.ctx, 2, "123456"), "expected missing credential to be rejected")
+}
@@ -1,6 +1,9 @@
 package services
 
 import (
+	"context"
+	"time"
+
 	"github.com/go-flow/flow"
 	"github.com/go-flow/template-api/domain/models"
 	"github.com/go-flow/template-api/pkg/paging"
@@ -13,25 +16,45 @@ type UserService interface {
 	UserService() string
 
 	// GetByID returns user model based on a provided id
-	GetByID(id uint64) (*models.User, error)
+	GetByID(ctx context.Context, id uint64) (*models.User, error)
+
+	// GetByIDWithFields returns user model based on a provided id with only fieldset fields loaded
+	GetByIDWithFields(ctx context.Context, id uint64, fields paging.Fields) (*models.User, error)
 
 	// GetByEmail returns user record based on a provided email
-	GetByEmail(email string) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
 
 	// GetAll returns all Users model for given paging users
-	GetAll(paginator *paging.Paginator) ([]*models.User, error)
+	GetAll(ctx context.Context, paginator *paging.Paginator) ([]*models.User, error)
+
+	// GetInvitees returns all users invited by any of given users
+	GetInvitees(ctx context.Context, inviterIDs []uint64) ([]*models.User, error)
+
+	// Search returns at most limit users matching full-text query, ordered by relevance
+	//
+	// private fields are matched only when private is true
+	Search(ctx context.Context, query string, limit int, private bool) ([]*models.UserSearchResult, error)
 
 	// Create new User object in database
-	Create(user *models.User) error
+	Create(ctx context.Context, user *models.User) error
 
 	// Update existing User object in database
-	Update(user *models.User) error
+	Update(ctx context.Context, user *models.User) error
 
 	// Save creates or updates user model based on ID user
-	Save(user *models.User) error
+	Save(ctx context.Context, user *models.User) error
 
-	// Delete User object from database
-	Delete(user *models.User) error
+	// Delete soft deletes User object
+	Delete(ctx context.Context, user *models.User) error
+
+	// Restore clears deletion of soft deleted user
+	Restore(ctx context.Context, id uint64) error
+
+	// Purge permanently deletes user, whether it is soft deleted or not
+	Purge(ctx context.Context, id uint64) error
+
+	// PurgeDeletedBefore permanently deletes users soft deleted before given time
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
 // NewUserService creates new UserService implementation
@@ -43,6 +66,9 @@ func NewUserService(app *flow.App) UserService {
 type userService struct {
 	// UserRepository implementation injected by dependency injection
 	UserRepository repositories.UserRepository
+
+	// SearchIndex implementation injected by dependency injection
+	SearchIndex repositories.SearchIndex
 }
 
 // UserService ensures interface implementation
@@ -51,40 +77,95 @@ func (svc *userService) UserService() string {
 }
 
 // GetByID returns user model based on a provided id
-func (svc *userService) GetByID(id uint64) (*models.User, error) {
-	return svc.UserRepository.GetByID(id)
+func (svc *userService) GetByID(ctx context.Context, id uint64) (*models.User, error) {
+	return svc.UserRepository.GetByID(ctx, id)
+}
+
+// GetByIDWithFields returns user model based on a provided id with only fieldset fields loaded
+func (svc *userService) GetByIDWithFields(ctx context.Context, id uint64, fields paging.Fields) (*models.User, error) {
+	return svc.UserRepository.GetByIDWithFields(ctx, id, fields)
 }
 
 // GetByEmail returns user record based on a provided email
-func (svc *userService) GetByEmail(email string) (*models.User, error) {
-	return svc.UserRepository.GetByEmail(email)
+func (svc *userService) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	return svc.UserRepository.GetByEmail(ctx, email)
 }
 
 // GetAll returns all Users model for given paging users
-func (svc *userService) GetAll(paginator *paging.Paginator) ([]*models.User, error) {
-	return svc.UserRepository.GetAll(paginator)
+func (svc *userService) GetAll(ctx context.Context, paginator *paging.Paginator) ([]*models.User, error) {
+	return svc.UserRepository.GetAll(ctx, paginator)
+}
+
+// GetInvitees returns all users invited by any of given users
+func (svc *userService) GetInvitees(ctx context.Context, inviterIDs []uint64) ([]*models.User, error) {
+	return svc.UserRepository.GetAllByInvitedByUserIDs(ctx, inviterIDs)
+}
+
+// Search returns at most limit users matching full-text query, ordered by relevance
+func (svc *userService) Search(ctx context.Context, query string, limit int, private bool) ([]*models.UserSearchResult, error) {
+	return svc.SearchIndex.Search(ctx, query, limit, private)
 }
 
 // Create new User object in database
-func (svc *userService) Create(user *models.User) error {
-	return svc.UserRepository.Create(user)
+func (svc *userService) Create(ctx context.Context, user *models.User) error {
+	if err := svc.UserRepository.Create(ctx, user); err != nil {
+		return err
+	}
+
+	return svc.SearchIndex.Index(ctx, user)
 }
 
 // Update existing User object in database
-func (svc *userService) Update(user *models.User) error {
-	return svc.UserRepository.Update(user)
+func (svc *userService) Update(ctx context.Context, user *models.User) error {
+	if err := svc.UserRepository.Update(ctx, user); err != nil {
+		return err
+	}
+
+	return svc.SearchIndex.Index(ctx, user)
 }
 
 // Save creates or updates user model based on ID user
-func (svc *userService) Save(user *models.User) error {
+func (svc *userService) Save(ctx context.Context, user *models.User) error {
 	if user.ID > 0 {
-		return svc.Update(user)
+		return svc.Update(ctx, user)
+	}
+
+	return svc.Create(ctx, user)
+}
+
+// Delete soft deletes User object
+func (svc *userService) Delete(ctx context.Context, user *models.User) error {
+	if err := svc.UserRepository.Delete(ctx, user); err != nil {
+		return err
+	}
+
+	return svc.SearchIndex.Remove(ctx, user.ID)
+}
+
+// Restore clears deletion of soft deleted user
+func (svc *userService) Restore(ctx context.Context, id uint64) error {
+	if err := svc.UserRepository.Restore(ctx, id); err != nil {
+		return err
+	}
+
+	user, err := svc.UserRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return svc.SearchIndex.Index(ctx, user)
+}
+
+// Purge permanently deletes user, whether it is soft deleted or not
+func (svc *userService) Purge(ctx context.Context, id uint64) error {
+	if err := svc.UserRepository.Purge(ctx, id); err != nil {
+		return err
 	}
 
-	return svc.Create(user)
+	return svc.SearchIndex.Remove(ctx, id)
 }
 
-// Delete User object from database
-func (svc *userService) Delete(user *models.User) error {
-	return svc.UserRepository.Delete(user)
+// PurgeDeletedBefore permanently deletes users soft deleted before given time
+func (svc *userService) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	return svc.UserRepository.PurgeDeletedBefore(ctx, before)
 }
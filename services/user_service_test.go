@@ -1,13 +1,16 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/go-flow/template-api/db"
 	"github.com/go-flow/template-api/domain/models"
 	"github.com/go-flow/template-api/mocks"
 	"github.com/go-flow/template-api/pkg/paging"
+	"github.com/jinzhu/gorm"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -16,27 +19,32 @@ import (
 type ServiceSuite struct {
 	suite.Suite
 
+	ctx                context.Context
 	service            UserService
 	userRepositoryMock *mocks.UserRepositoryMock
+	searchIndexMock    *mocks.SearchIndexMock
 }
 
-//TestServiceSuite is ure repository test suite runner
+// TestServiceSuite is ure repository test suite runner
 func TestServiceSuite(t *testing.T) {
 	suite.Run(t, new(ServiceSuite))
 }
 
 // SetupSuite configures suite for unit testing
 func (s *ServiceSuite) SetupSuite() {
-	// anything to do speciffic to setup this suite?
+	// mocks expect exactly this context, so tests verify it reaches repositories
+	s.ctx = db.WithRequestID(context.Background(), "request-id")
 }
 
 // BeforeTest is called before every test in Service Suite
 // it ensures that every test is setup correctly
 func (s *ServiceSuite) BeforeTest(_, _ string) {
 	s.userRepositoryMock = mocks.NewUserRepositoryMock()
+	s.searchIndexMock = mocks.NewSearchIndexMock()
 
 	s.service = &userService{
 		UserRepository: s.userRepositoryMock,
+		SearchIndex:    s.searchIndexMock,
 	}
 }
 
@@ -59,19 +67,19 @@ func (s *ServiceSuite) Test_GetByID() {
 	errNotFound := errors.New("user not found")
 
 	// set method call expectations for existing user
-	s.userRepositoryMock.On("GetByID", uint64(1)).Return(model, nil)
+	s.userRepositoryMock.On("GetByID", s.ctx, uint64(1)).Return(model, nil)
 	// set method call expectations for non existing user
-	s.userRepositoryMock.On("GetByID", uint64(2)).Return(nil, errNotFound)
+	s.userRepositoryMock.On("GetByID", s.ctx, uint64(2)).Return(nil, errNotFound)
 
 	// make actual call
-	user, err := s.service.GetByID(1)
+	user, err := s.service.GetByID(s.ctx, 1)
 
 	// assert results
 	assert.Nil(s.T(), err, "expected error from first call to be <nil>, got %v", err)
 	assert.Equal(s.T(), model, user, "expected test model to be Equal to returned user model")
 
 	// make negative call
-	user, err = s.service.GetByID(2)
+	user, err = s.service.GetByID(s.ctx, 2)
 
 	if assert.NotNil(s.T(), err, "expected returned error object to have value") {
 		assert.Equal(s.T(), errNotFound, err, "expected err object to have `user not found` value, got %v", err)
@@ -80,6 +88,18 @@ func (s *ServiceSuite) Test_GetByID() {
 
 }
 
+func (s *ServiceSuite) Test_GetByIDWithFields() {
+	model := &models.User{ID: 1, Email: "sedin@mop.ba"}
+	fields := paging.Fields{"email"}
+
+	s.userRepositoryMock.On("GetByIDWithFields", s.ctx, uint64(1), fields).Return(model, nil)
+
+	user, err := s.service.GetByIDWithFields(s.ctx, 1, fields)
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Equal(s.T(), model, user, "expected test model to be Equal to returned user model")
+}
+
 func (s *ServiceSuite) Test_GetByEmail() {
 	// test model
 	model := &models.User{
@@ -92,10 +112,10 @@ func (s *ServiceSuite) Test_GetByEmail() {
 	}
 
 	// set method call expectations for existing user
-	s.userRepositoryMock.On("GetByEmail", model.Email).Return(model, nil)
+	s.userRepositoryMock.On("GetByEmail", s.ctx, model.Email).Return(model, nil)
 
 	// make actual call
-	user, err := s.service.GetByEmail(model.Email)
+	user, err := s.service.GetByEmail(s.ctx, model.Email)
 
 	// assert results
 	assert.Nil(s.T(), err, "expected error from first call to be <nil>, got %v", err)
@@ -126,10 +146,10 @@ func (s *ServiceSuite) Test_GetAll() {
 	paginator := paging.NewWithDefaults()
 
 	// set method call expectations for existing user
-	s.userRepositoryMock.On("GetAll", paginator).Return(model, nil)
+	s.userRepositoryMock.On("GetAll", s.ctx, paginator).Return(model, nil)
 
 	// make actual call
-	user, err := s.service.GetAll(paginator)
+	user, err := s.service.GetAll(s.ctx, paginator)
 
 	// assert results
 	assert.Nil(s.T(), err, "expected error from first call to be <nil>, got %v", err)
@@ -151,14 +171,15 @@ func (s *ServiceSuite) Test_Create() {
 	}
 
 	// set method call expectations for existing user
-	s.userRepositoryMock.On("Create", user).Return(nil).Run(func(args mock.Arguments) {
+	s.userRepositoryMock.On("Create", s.ctx, user).Return(nil).Run(func(args mock.Arguments) {
 		// set model ID to be the same as model object
-		u := args.Get(0).(*models.User)
+		u := args.Get(1).(*models.User)
 		u.ID = model.ID
 	})
+	s.searchIndexMock.On("Index", s.ctx, user).Return(nil)
 
 	// make actual call
-	err := s.service.Save(user)
+	err := s.service.Save(s.ctx, user)
 
 	// assert results
 	assert.Nil(s.T(), err, "expected error from first call to be <nil>, got %v", err)
@@ -181,15 +202,16 @@ func (s *ServiceSuite) Test_Update() {
 	}
 
 	// set method call expectations for existing user
-	s.userRepositoryMock.On("Update", user).Return(nil).Run(func(args mock.Arguments) {
+	s.userRepositoryMock.On("Update", s.ctx, user).Return(nil).Run(func(args mock.Arguments) {
 		// set model ID to be the same as model object
-		u := args.Get(0).(*models.User)
+		u := args.Get(1).(*models.User)
 		u.FirstName = model.FirstName
 		u.LastName = model.LastName
 	})
+	s.searchIndexMock.On("Index", s.ctx, user).Return(nil)
 
 	// make actual call
-	err := s.service.Save(user)
+	err := s.service.Save(s.ctx, user)
 
 	// assert results
 	assert.Nil(s.T(), err, "expected error from first call to be <nil>, got %v", err)
@@ -206,12 +228,88 @@ func (s *ServiceSuite) Test_Delete() {
 	}
 
 	// set method call expectations for existing user
-	s.userRepositoryMock.On("DeleteByID", uint64(1)).Return(nil)
-	//s.userRepositoryMock.On("DeleteByID", uint64(1)).Return(nil)
+	s.userRepositoryMock.On("DeleteByID", s.ctx, uint64(1)).Return(nil)
+	//s.userRepositoryMock.On("DeleteByID", s.ctx, uint64(1)).Return(nil)
+	s.searchIndexMock.On("Remove", s.ctx, uint64(1)).Return(nil)
+
+	// make actual call
+	err := s.service.Delete(s.ctx, model)
+
+	// assert results
+	assert.Nil(s.T(), err, "expected error from first call to be <nil>, got %v", err)
+}
+
+func (s *ServiceSuite) Test_Restore() {
+	model := &models.User{ID: 1, FirstName: "Sedin"}
+
+	s.userRepositoryMock.On("Restore", s.ctx, uint64(1)).Return(nil)
+	s.userRepositoryMock.On("GetByID", s.ctx, uint64(1)).Return(model, nil)
+	s.searchIndexMock.On("Index", s.ctx, model).Return(nil)
+
+	err := s.service.Restore(s.ctx, 1)
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	s.searchIndexMock.AssertCalled(s.T(), "Index", s.ctx, model)
+}
+
+func (s *ServiceSuite) Test_Purge() {
+	s.userRepositoryMock.On("Purge", s.ctx, uint64(1)).Return(gorm.ErrRecordNotFound)
+
+	err := s.service.Purge(s.ctx, 1)
+
+	assert.Equal(s.T(), gorm.ErrRecordNotFound, err, "expected record not found error, got %v", err)
+	s.searchIndexMock.AssertNotCalled(s.T(), "Remove", s.ctx, uint64(1))
+}
+
+func (s *ServiceSuite) Test_PurgeDeletedBefore() {
+	before := time.Now()
+
+	s.userRepositoryMock.On("PurgeDeletedBefore", s.ctx, before).Return(int64(2), nil)
+
+	purged, err := s.service.PurgeDeletedBefore(s.ctx, before)
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Equal(s.T(), int64(2), purged, "expected 2 purged users, got %v", purged)
+}
+
+func (s *ServiceSuite) Test_GetInvitees() {
+	inviterID := uint64(1)
+
+	// test model
+	model := []*models.User{
+		{
+			ID:              2,
+			FirstName:       "Sedin",
+			LastName:        "Dugum",
+			Email:           "sedin+2@mop.ba",
+			InvitedByUserID: &inviterID,
+		},
+	}
+
+	// set method call expectations for existing invitees
+	s.userRepositoryMock.On("GetAllByInvitedByUserIDs", s.ctx, []uint64{inviterID}).Return(model, nil)
 
 	// make actual call
-	err := s.service.Delete(model)
+	users, err := s.service.GetInvitees(s.ctx, []uint64{inviterID})
 
 	// assert results
 	assert.Nil(s.T(), err, "expected error from first call to be <nil>, got %v", err)
+	assert.Equal(s.T(), model, users, "expected test model to be Equal to returned invitees")
+}
+
+func (s *ServiceSuite) Test_Search() {
+	model := []*models.UserSearchResult{
+		{
+			User:       &models.User{ID: 1, FirstName: "Sedin", LastName: "Dugum"},
+			Score:      1.5,
+			Highlights: map[string]string{"first_name": "<mark>Sedin</mark>"},
+		},
+	}
+
+	s.searchIndexMock.On("Search", s.ctx, "sedin", 20, false).Return(model, nil)
+
+	results, err := s.service.Search(s.ctx, "sedin", 20, false)
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Equal(s.T(), model, results, "expected search results to be returned from index")
 }
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+
+	"github.com/go-flow/flow"
+	apperrors "github.com/go-flow/template-api/domain/errors"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/repositories"
+)
+
+// ErrUnchangedStatus is returned when recording transition which does not change user status
+var ErrUnchangedStatus = apperrors.Conflict(apperrors.CodeUnchangedStatus, "status transition does not change status")
+
+// UserStatusTransitionService defines set of available operations around user status transitions
+type UserStatusTransitionService interface {
+	// UserStatusTransitionService ensures interface implementation
+	UserStatusTransitionService() string
+
+	// GetAllByUserID returns status transitions of given user, newest first
+	GetAllByUserID(ctx context.Context, userID uint64) ([]*models.UserStatusTransition, error)
+
+	// Record stores user status transition
+	Record(ctx context.Context, transition *models.UserStatusTransition) error
+}
+
+// NewUserStatusTransitionService creates new UserStatusTransitionService implementation
+func NewUserStatusTransitionService(app *flow.App) UserStatusTransitionService {
+	return &userStatusTransitionService{}
+}
+
+// userStatusTransitionService struct that implements UserStatusTransitionService interface
+type userStatusTransitionService struct {
+	// UserStatusTransitionRepository implementation injected by dependency injection
+	UserStatusTransitionRepository repositories.UserStatusTransitionRepository
+}
+
+// UserStatusTransitionService ensures interface implementation
+func (svc *userStatusTransitionService) UserStatusTransitionService() string {
+	return "userStatusTransitionService"
+}
+
+// GetAllByUserID returns status transitions of given user, newest first
+func (svc *userStatusTransitionService) GetAllByUserID(ctx context.Context, userID uint64) ([]*models.UserStatusTransition, error) {
+	return svc.UserStatusTransitionRepository.GetAllByUserID(ctx, userID)
+}
+
+// Record stores user status transition
+//
+// suspended to suspended transition is allowed as it changes suspension reason or expiry
+func (svc *userStatusTransitionService) Record(ctx context.Context, transition *models.UserStatusTransition) error {
+	if transition.FromStatus == transition.ToStatus && transition.ToStatus != models.UserStatusSuspended {
+		return ErrUnchangedStatus
+	}
+
+	return svc.UserStatusTransitionRepository.Create(ctx, transition)
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserStatusTransitionServiceSuite struct {
+	suite.Suite
+
+	service                            UserStatusTransitionService
+	userStatusTransitionRepositoryMock *mocks.UserStatusTransitionRepositoryMock
+}
+
+// TestUserStatusTransitionServiceSuite is user status transition service test suite runner
+func TestUserStatusTransitionServiceSuite(t *testing.T) {
+	suite.Run(t, new(UserStatusTransitionServiceSuite))
+}
+
+// BeforeTest is called before every test in UserStatusTransitionServiceSuite
+func (s *UserStatusTransitionServiceSuite) BeforeTest(_, _ string) {
+	s.userStatusTransitionRepositoryMock = mocks.NewUserStatusTransitionRepositoryMock()
+
+	s.service = &userStatusTransitionService{
+		UserStatusTransitionRepository: s.userStatusTransitionRepositoryMock,
+	}
+}
+
+// AfterTest ensures that all UserStatusTransitionServiceSuite expectations were met
+func (s *UserStatusTransitionServiceSuite) AfterTest(_, _ string) {
+	s.userStatusTransitionRepositoryMock.AssertExpectations(s.T())
+}
+
+func (s *UserStatusTransitionServiceSuite) Test_Record() {
+	actorID := uint64(1)
+	transition := &models.UserStatusTransition{
+		UserID:      2,
+		ActorUserID: &actorID,
+		FromStatus:  models.UserStatusInactive,
+		ToStatus:    models.UserStatusActive,
+	}
+
+	s.userStatusTransitionRepositoryMock.On("Create", context.Background(), transition).Return(nil)
+
+	err := s.service.Record(context.Background(), transition)
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+}
+
+func (s *UserStatusTransitionServiceSuite) Test_Record_Unchanged() {
+	transition := &models.UserStatusTransition{
+		UserID:     2,
+		FromStatus: models.UserStatusActive,
+		ToStatus:   models.UserStatusActive,
+	}
+
+	err := s.service.Record(context.Background(), transition)
+
+	assert.Equal(s.T(), ErrUnchangedStatus, err, "expected unchanged status to be rejected, got %v", err)
+}
+
+func (s *UserStatusTransitionServiceSuite) Test_Record_Resuspend() {
+	transition := &models.UserStatusTransition{
+		UserID:     2,
+		FromStatus: models.UserStatusSuspended,
+		ToStatus:   models.UserStatusSuspended,
+		Reason:     "extended",
+	}
+
+	s.userStatusTransitionRepositoryMock.On("Create", context.Background(), transition).Return(nil)
+
+	err := s.service.Record(context.Background(), transition)
+
+	assert.Nil(s.T(), err, "expected suspension change to be recorded, got %v", err)
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-flow/flow"
+	apperrors "github.com/go-flow/template-api/domain/errors"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/pkg/token"
+	"github.com/go-flow/template-api/repositories"
+	"github.com/jinzhu/gorm"
+)
+
+// userTokenSize is number of random bytes used for user tokens
+const userTokenSize = 32
+
+// ErrInvalidUserToken is returned when user token is unknown, expired or already used
+var ErrInvalidUserToken = apperrors.Validation(apperrors.CodeInvalidUserToken, "invalid or expired token")
+
+// UserTokenService defines set of available operations around UserToken model
+type UserTokenService interface {
+	// UserTokenService ensures interface implementation
+	UserTokenService() string
+
+	// GetLatest returns most recently issued user token for given purpose
+	GetLatest(ctx context.Context, userID uint64, purpose string) (*models.UserToken, error)
+
+	// Issue invalidates previously issued tokens and creates new token for given purpose
+	//
+	// plain text token is returned only once, database holds its hash
+	Issue(ctx context.Context, userID uint64, purpose string, ttl time.Duration) (string, *models.UserToken, error)
+
+	// Consume validates plain text token for given purpose and marks it as used
+	Consume(ctx context.Context, purpose string, plainToken string) (*models.UserToken, error)
+}
+
+// NewUserTokenService creates new UserTokenService implementation
+func NewUserTokenService(app *flow.App) UserTokenService {
+	return &userTokenService{}
+}
+
+// userTokenService struct that implements UserTokenService interface
+type userTokenService struct {
+	// UserTokenRepository implementation injected by dependency injection
+	UserTokenRepository repositories.UserTokenRepository
+}
+
+// UserTokenService ensures interface implementation
+func (svc *userTokenService) UserTokenService() string {
+	return "userTokenService"
+}
+
+// GetLatest returns most recently issued user token for given purpose
+func (svc *userTokenService) GetLatest(ctx context.Context, userID uint64, purpose string) (*models.UserToken, error) {
+	return svc.UserTokenRepository.GetLatestByUserID(ctx, userID, purpose)
+}
+
+// Issue invalidates previously issued tokens and creates new token for given purpose
+func (svc *userTokenService) Issue(ctx context.Context, userID uint64, purpose string, ttl time.Duration) (string, *models.UserToken, error) {
+	if err := svc.UserTokenRepository.InvalidateAllByUserID(ctx, userID, purpose); err != nil {
+		return "", nil, err
+	}
+
+	plain, err := token.Random(userTokenSize)
+	if err != nil {
+		return "", nil, err
+	}
+
+	model := &models.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: token.Hash(plain),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	if err := svc.UserTokenRepository.Create(ctx, model); err != nil {
+		return "", nil, err
+	}
+
+	return plain, model, nil
+}
+
+// Consume validates plain text token for given purpose and marks it as used
+func (svc *userTokenService) Consume(ctx context.Context, purpose string, plainToken string) (*models.UserToken, error) {
+	model, err := svc.UserTokenRepository.GetByTokenHash(ctx, purpose, token.Hash(plainToken))
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, ErrInvalidUserToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !model.IsUsable(time.Now()) {
+		return nil, ErrInvalidUserToken
+	}
+
+	used, err := svc.UserTokenRepository.MarkUsed(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInvalidUserToken
+	}
+
+	return model, nil
+}
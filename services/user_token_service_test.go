@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-flow/template-api/db"
+	"github.com/go-flow/template-api/domain/models"
+	"github.com/go-flow/template-api/mocks"
+	"github.com/go-flow/template-api/pkg/token"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserTokenServiceSuite struct {
+	suite.Suite
+
+	ctx                     context.Context
+	service                 UserTokenService
+	userTokenRepositoryMock *mocks.UserTokenRepositoryMock
+}
+
+// TestUserTokenServiceSuite is user token service test suite runner
+func TestUserTokenServiceSuite(t *testing.T) {
+	suite.Run(t, new(UserTokenServiceSuite))
+}
+
+// SetupSuite configures suite for unit testing
+func (s *UserTokenServiceSuite) SetupSuite() {
+	s.ctx = db.WithRequestID(context.Background(), "request-id")
+}
+
+// BeforeTest is called before every test in UserTokenServiceSuite
+func (s *UserTokenServiceSuite) BeforeTest(_, _ string) {
+	s.userTokenRepositoryMock = mocks.NewUserTokenRepositoryMock()
+
+	s.service = &userTokenService{
+		UserTokenRepository: s.userTokenRepositoryMock,
+	}
+}
+
+// AfterTest ensures that all UserTokenServiceSuite expectations were met
+func (s *UserTokenServiceSuite) AfterTest(_, _ string) {
+	s.userTokenRepositoryMock.AssertExpectations(s.T())
+}
+
+func (s *UserTokenServiceSuite) Test_Issue() {
+	purpose := models.UserTokenPurposeEmailVerification
+
+	s.userTokenRepositoryMock.On("InvalidateAllByUserID", s.ctx, uint64(1), purpose).Return(nil)
+	s.userTokenRepositoryMock.On("Create", s.ctx, mock.AnythingOfType("*models.UserToken")).Return(nil)
+
+	plain, model, err := s.service.Issue(s.ctx, 1, purpose, time.Hour)
+
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Equal(s.T(), token.Hash(plain), model.TokenHash, "expected stored token to be hash of plain token")
+	assert.Equal(s.T(), purpose, model.Purpose, "expected token purpose to be %s, got %s", purpose, model.Purpose)
+	assert.True(s.T(), model.IsUsable(time.Now()), "expected issued token to be usable")
+}
+
+func (s *UserTokenServiceSuite) Test_Consume() {
+	purpose := models.UserTokenPurposeEmailVerification
+
+	valid := &models.UserToken{ID: 1, UserID: 1, Purpose: purpose, ExpiresAt: time.Now().Add(time.Hour)}
+	expired := &models.UserToken{ID: 2, UserID: 1, Purpose: purpose, ExpiresAt: time.Now().Add(-time.Hour)}
+	raced := &models.UserToken{ID: 3, UserID: 1, Purpose: purpose, ExpiresAt: time.Now().Add(time.Hour)}
+
+	s.userTokenRepositoryMock.On("GetByTokenHash", s.ctx, purpose, token.Hash("valid")).Return(valid, nil)
+	s.userTokenRepositoryMock.On("GetByTokenHash", s.ctx, purpose, token.Hash("expired")).Return(expired, nil)
+	s.userTokenRepositoryMock.On("GetByTokenHash", s.ctx, purpose, token.Hash("raced")).Return(raced, nil)
+	s.userTokenRepositoryMock.On("GetByTokenHash", s.ctx, purpose, token.Hash("unknown")).Return(nil, gorm.ErrRecordNotFound)
+	s.userTokenRepositoryMock.On("MarkUsed", s.ctx, valid).Return(true, nil)
+	s.userTokenRepositoryMock.On("MarkUsed", s.ctx, raced).Return(false, nil)
+
+	model, err := s.service.Consume(s.ctx, purpose, "valid")
+	assert.Nil(s.T(), err, "expected error to be <nil>, got %v", err)
+	assert.Equal(s.T(), valid, model, "expected consumed token to be returned")
+
+	for _, plain := range []string{"expired", "raced", "unknown"} {
+		_, err = s.service.Consume(s.ctx, purpose, plain)
+		assert.Equal(s.T(), ErrInvalidUserToken, err, "expected `%s` token to be rejected, got %v", plain, err)
+	}
+}